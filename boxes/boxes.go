@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/stelmanjones/termtools/text"
+	"github.com/stelmanjones/termtools/theme"
 )
 
 // DefaultBox is a standard box.
@@ -101,9 +102,19 @@ type Box struct {
 	content []*text.Line
 	Padding Padding
 	Width   int
+	theme   *theme.Theme
+}
+
+// styles returns the theme of the box, which defaults to theme.Current.
+func (b *Box) styles() *theme.Theme {
+	if b.theme == nil {
+		return theme.Current()
+	}
+	return b.theme
 }
 
 func (b *Box) buildHeader() string {
+	t := b.styles()
 	var sb strings.Builder
 	oddPadding := ""
 	if text.OddVisibleLength(b.title) {
@@ -113,9 +124,11 @@ func (b *Box) buildHeader() string {
 	padding := strings.Repeat(b.Border.Horizontal, (b.Width-2-titleWidth)/2)
 	sb.Grow(titleWidth + 4 + (b.Width - 2 - titleWidth) + len(oddPadding))
 
-	sb.WriteString("\n" + b.Border.TopLeft)
-	sb.WriteString(padding + b.title + padding + oddPadding)
-	sb.WriteString(b.Border.TopRight)
+	sb.WriteString("\n" + t.Border.Render(b.Border.TopLeft+padding))
+	if b.title != "" {
+		sb.WriteString(t.Title.Render(b.title))
+	}
+	sb.WriteString(t.Border.Render(padding + oddPadding + b.Border.TopRight))
 	return sb.String()
 }
 
@@ -123,8 +136,8 @@ func (b *Box) buildFooter() string {
 	var sb strings.Builder
 	sb.WriteString(b.Border.BottomLeft)
 	sb.WriteString(strings.Repeat(b.Border.Horizontal, b.Width-2))
-	sb.WriteString(b.Border.BottomRight + "\n")
-	return sb.String()
+	sb.WriteString(b.Border.BottomRight)
+	return b.styles().Border.Render(sb.String()) + "\n"
 }
 
 // Sprint returns the rendered box as a string.
@@ -132,7 +145,8 @@ func (b *Box) Sprint(s ...string) string {
 	var sb strings.Builder
 	lenWithoutCorners := b.Width - 2
 	usableWidth := b.Width - (2 + b.Padding.Left + b.Padding.Right)
-	emptyLine := b.Border.Vertical + strings.Repeat(" ", lenWithoutCorners) + b.Border.Vertical
+	vertical := b.styles().Border.Render(b.Border.Vertical)
+	emptyLine := vertical + strings.Repeat(" ", lenWithoutCorners) + vertical
 
 	// Header
 	sb.WriteString(b.buildHeader() + "\n")
@@ -153,7 +167,7 @@ func (b *Box) Sprint(s ...string) string {
 		if text.OddVisibleLength(l.Value()) {
 			oddPadding = " "
 		}
-		sb.WriteString(b.Border.Vertical + strings.Repeat(" ", b.Padding.Left) + l.Value() + oddPadding + strings.Repeat(" ", b.Padding.Right) + b.Border.Vertical + "\n")
+		sb.WriteString(vertical + strings.Repeat(" ", b.Padding.Left) + l.Value() + oddPadding + strings.Repeat(" ", b.Padding.Right) + vertical + "\n")
 	}
 
 	// Bottom Padding
@@ -173,6 +187,13 @@ func (b *Box) WithTitle(title string) *Box {
 	return b
 }
 
+// WithTheme sets the theme of the box, replacing theme.Current.
+// The border is rendered in its Border style and the title in its Title style.
+func (b *Box) WithTheme(t *theme.Theme) *Box {
+	b.theme = t
+	return b
+}
+
 // Print prints the box to it's internal writer.
 func (b *Box) Print(s ...string) {
 	b.writer.Write([]byte(b.Sprint(s...)))
@@ -2,11 +2,21 @@ module github.com/stelmanjones/termtools/boxes
 
 go 1.23.0
 
-require github.com/stelmanjones/termtools/text v0.0.0-20240810205715-64ac7a9ad647
+require (
+	github.com/stelmanjones/termtools/text v0.0.0-20240810205715-64ac7a9ad647
+	github.com/stelmanjones/termtools/theme v0.0.0-20261019160604-c8b5e8030ca7
+)
 
 require (
+	github.com/BurntSushi/toml v1.4.0 // indirect
+	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
+	github.com/charmbracelet/lipgloss v0.10.0 // indirect
 	github.com/gookit/color v1.5.4 // indirect
+	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/muesli/reflow v0.3.0 // indirect
+	github.com/muesli/termenv v0.15.2 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 	github.com/xo/terminfo v0.0.0-20210125001918-ca9a967f8778 // indirect
 	golang.org/x/sys v0.10.0 // indirect
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/stelmanjones/termtools/spin"
+	"golang.org/x/term"
+)
+
+// spinCommand runs "termtools spin", which previews spinner variants.
+func spinCommand(args []string) error {
+	flags := flag.NewFlagSet("spin", flag.ContinueOnError)
+	list := flags.Bool("list", false, "preview all registered spinner variants")
+	file := flags.String("file", "", "register the variants of a cli-spinners JSON `file` before previewing")
+	duration := flags.Duration("duration", 5*time.Second, "how long the preview runs")
+	if err := flags.Parse(args); errors.Is(err, flag.ErrHelp) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if *file != "" {
+		variants, err := spin.LoadVariants(*file)
+		if err != nil {
+			return err
+		}
+		for name, v := range variants {
+			spin.Register(name, v)
+		}
+	}
+	if !*list {
+		flags.Usage()
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *duration)
+	defer cancel()
+	return previewVariants(ctx, os.Stdout)
+}
+
+// previewVariants animates every registered variant next to its name until
+// ctx is done. Unless f is a terminal, the frames of each variant are listed once.
+func previewVariants(ctx context.Context, f *os.File) error {
+	names := spin.Names()
+	width := 0
+	for _, name := range names {
+		width = max(width, len(name))
+	}
+	variants := make([]spin.SpinnerVariant, len(names))
+	for i, name := range names {
+		variants[i], _ = spin.Lookup(name)
+	}
+
+	if !term.IsTerminal(int(f.Fd())) {
+		for i, name := range names {
+			frames := []string{}
+			for c := range variants[i].All() {
+				frames = append(frames, c)
+			}
+			fmt.Fprintf(f, "%-*s  %s\n", width, name, strings.Join(frames, " "))
+		}
+		return nil
+	}
+
+	fmt.Fprint(f, "\033[?25l")
+	defer fmt.Fprint(f, "\033[?25h")
+	start := time.Now()
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	for drawn := false; ; drawn = true {
+		drawFrames(f, names, variants, width, time.Since(start), drawn)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// drawFrames draws the frames of the variants shown elapsed after the preview
+// started, replacing the previous frames if redraw is set.
+func drawFrames(w io.Writer, names []string, variants []spin.SpinnerVariant, width int, elapsed time.Duration, redraw bool) {
+	var sb strings.Builder
+	if redraw {
+		fmt.Fprintf(&sb, "\033[%dF", len(names))
+	}
+	for i, name := range names {
+		fmt.Fprintf(&sb, "%-*s  %s\033[K\n", width, name, variants[i].FrameAt(elapsed))
+	}
+	fmt.Fprint(w, sb.String())
+}
@@ -16,6 +16,8 @@ replace github.com/stelmanjones/termtools/text => ./text
 
 replace github.com/stelmanjones/termtools/tty => ./tty
 
+replace github.com/stelmanjones/termtools/theme => ./theme
+
 go 1.23.1
 
 require (
@@ -28,6 +30,7 @@ require (
 
 require (
 	github.com/stelmanjones/termtools/text v0.0.0-20240810205715-64ac7a9ad647 // indirect
+	github.com/stelmanjones/termtools/theme v0.0.0-00010101000000-000000000000 // indirect
 	golang.org/x/net v0.24.0 // indirect
 )
 
@@ -58,7 +61,7 @@ require (
 	github.com/wI2L/jettison v0.7.4 // indirect
 	github.com/yusufpapurcu/wmi v1.2.3 // indirect
 	golang.org/x/image v0.12.0 // indirect
-	golang.org/x/term v0.19.0 // indirect
+	golang.org/x/term v0.19.0
 )
 
 require (
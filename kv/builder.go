@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/emirpasic/gods/maps/hashmap"
+	"github.com/stelmanjones/termtools/theme"
 )
 
 const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
@@ -15,6 +16,7 @@ type Builder struct {
 	address string
 	limit   int
 	auth    bool
+	theme   *theme.Theme
 }
 
 // WithAuth sets the authentication flag and token for the KV.
@@ -36,6 +38,12 @@ func (b *Builder) WithLimit(limit int) *Builder {
 	return b
 }
 
+// WithTheme sets the theme of the log output of the KV, replacing theme.Current.
+func (b *Builder) WithTheme(t *theme.Theme) *Builder {
+	b.theme = t
+	return b
+}
+
 // WithRandomBearerToken sets a random bearer token for the KV.
 func WithRandomBearerToken() *Builder {
 	return &Builder{
@@ -46,7 +54,7 @@ func WithRandomBearerToken() *Builder {
 
 // Build returns a new KV instance with the configured options.
 func (b *Builder) Build() *KV {
-	return &KV{
+	k := &KV{
 		mux:  &sync.RWMutex{},
 		data: hashmap.New(),
 
@@ -55,7 +63,10 @@ func (b *Builder) Build() *KV {
 		address: b.address,
 		limit:   b.limit,
 		batch:   []interface{}{},
+		theme:   b.theme,
 	}
+	k.logger = newLogger(k.styles())
+	return k
 }
 
 // New returns a new KV builder.
@@ -11,15 +11,17 @@ require (
 	github.com/charmbracelet/log v0.4.0
 	github.com/emirpasic/gods v1.18.1
 	github.com/gorilla/mux v1.8.1
+	github.com/stelmanjones/termtools/theme v0.0.0-20261019160604-c8b5e8030ca7
 )
 
 require github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
 
 require (
+	github.com/BurntSushi/toml v1.4.0 // indirect
 	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
-	github.com/charmbracelet/lipgloss v0.10.0 // indirect
+	github.com/charmbracelet/lipgloss v0.10.0
 	github.com/go-logfmt/logfmt v0.6.0 // indirect
-	github.com/gookit/color v1.5.4
+	github.com/gookit/color v1.5.4 // indirect
 	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mattn/go-runewidth v0.0.16 // indirect
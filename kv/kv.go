@@ -11,10 +11,9 @@ import (
 	sjson "github.com/bitly/go-simplejson"
 	"github.com/charmbracelet/log"
 	"github.com/emirpasic/gods/maps/hashmap"
-	"github.com/gookit/color"
 	"github.com/gorilla/mux"
-	"github.com/stelmanjones/termtools/internal/theme"
 	"github.com/stelmanjones/termtools/kv/errors"
+	"github.com/stelmanjones/termtools/theme"
 )
 
 // Option defines a function signature for options used to configure a KV instance.
@@ -29,6 +28,8 @@ type KV struct {
 	batch   []interface{}
 	limit   int
 	auth    bool
+	theme   *theme.Theme
+	logger  *log.Logger
 }
 
 // func (k *KV) AddBatch(keyvals ...interface{}) error {
@@ -37,7 +38,7 @@ type KV struct {
 // 	if len(keyvals)%2 != 0 {
 // 		return errors.ErrMissingValue
 // 	}
-// 	logger.Debug("ADDED TO BATCH", "keyvals", keyvals)
+// 	k.logger.Debug("ADDED TO BATCH", "keyvals", keyvals)
 // 	k.batch = append(k.batch, keyvals...)
 // 	return nil
 // }
@@ -45,7 +46,7 @@ type KV struct {
 // func (k *KV) CancelBatch() {
 // 	k.mux.Lock()
 // 	defer k.mux.Unlock()
-// 	logger.Debug("Cancelled batch")
+// 	k.logger.Debug("Cancelled batch")
 // 	k.batch = []interface{}{}
 // }
 //
@@ -55,7 +56,7 @@ type KV struct {
 // 	for i := 0; i < len(k.batch); i += 2 {
 // 		k.data.Put(k.batch[i], k.batch[i+1])
 // 	}
-// 	logger.Debug("Committed batch")
+// 	k.logger.Debug("Committed batch")
 // 	k.batch = []interface{}{}
 // }
 
@@ -77,11 +78,16 @@ var lvl = func() log.Level {
 	}
 }()
 
-var logger = log.NewWithOptions(os.Stderr, log.Options{
-	Level:           lvl,
-	Prefix:          "KV",
-	ReportTimestamp: true,
-})
+// newLogger returns a logger for the log output of a KV, styled with t.
+func newLogger(t *theme.Theme) *log.Logger {
+	logger := log.NewWithOptions(os.Stderr, log.Options{
+		Level:           lvl,
+		Prefix:          "KV",
+		ReportTimestamp: true,
+	})
+	logger.SetStyles(logStyles(t))
+	return logger
+}
 
 // Data returns a snapshot of the current data in the KV store.
 func (k *KV) Data() *hashmap.Map {
@@ -91,17 +97,17 @@ func (k *KV) Data() *hashmap.Map {
 // Set stores a value associated with a key in the KV store.
 func (k *KV) Set(key string, value interface{}) error {
 	if k.limit > 0 && k.data.Size() >= k.limit {
-		logger.Warn(theme.Warning.Render("TABLE FULL"))
+		k.logger.Warn(k.styles().Warning.Render("TABLE FULL"))
 		return errors.ErrTableFull
 	}
 	k.mux.Lock()
 	defer k.mux.Unlock()
 	if !k.Has(key) {
 		k.data.Put(key, value)
-		logger.Debug(theme.Warning.Render("SET"), key, value)
+		k.logger.Debug(k.styles().Warning.Render("SET"), key, value)
 		return nil
 	}
-	logger.Error("Key '%v' already exists.", key)
+	k.logger.Error("Key '%v' already exists.", key)
 	return errors.ErrKeyExists
 }
 
@@ -115,14 +121,14 @@ func (k *KV) SetMany(keyvals ...interface{}) error {
 	for i := 0; i < len(keyvals); i += 2 {
 
 		if k.limit > 0 && k.data.Size() >= k.limit {
-			logger.Warn(theme.Warning.Render("TABLE FULL"))
+			k.logger.Warn(k.styles().Warning.Render("TABLE FULL"))
 			return errors.ErrTableFull
 		}
 		if !k.Has(keyvals[i].(string)) {
 			k.data.Put(keyvals[i].(string), keyvals[i+1])
-			logger.Debug(theme.Warning.Render("SET"), keyvals[i].(string), keyvals[i+1])
+			k.logger.Debug(k.styles().Warning.Render("SET"), keyvals[i].(string), keyvals[i+1])
 		}
-		logger.Error("Key '%v' already exists.", keyvals[i].(string))
+		k.logger.Error("Key '%v' already exists.", keyvals[i].(string))
 		return errors.ErrKeyExists
 	}
 	return nil
@@ -136,7 +142,7 @@ func (k *KV) Update(key string, value interface{}) error {
 		return errors.ErrKeyNotFound
 	}
 	k.data.Put(key, value)
-	logger.Debug(theme.AccentBlue.Render("UPDATED"), key, value)
+	k.logger.Debug(k.styles().Info.Render("UPDATED"), key, value)
 	return nil
 }
 
@@ -147,11 +153,11 @@ func (k *KV) UpdateMany(keyvals ...interface{}) error {
 	}
 	for i := 0; i < len(keyvals); i += 2 {
 		if !k.Has(keyvals[i].(string)) {
-			logger.Error("Key '%v' does not exist.", keyvals[i].(string))
+			k.logger.Error("Key '%v' does not exist.", keyvals[i].(string))
 			return errors.ErrKeyNotFound
 		}
 		k.data.Put(keyvals[i].(string), keyvals[i+1])
-		logger.Debug(theme.AccentBlue.Render("UPDATED"), keyvals[i].(string), keyvals[i+1])
+		k.logger.Debug(k.styles().Info.Render("UPDATED"), keyvals[i].(string), keyvals[i+1])
 	}
 	return nil
 }
@@ -161,7 +167,7 @@ func (k *KV) Get(key string) (interface{}, error) {
 	k.mux.RLock()
 	defer k.mux.RUnlock()
 	if value, found := k.data.Get(key); found {
-		logger.Debug(theme.AccentGreen.Render("GET"), key, value)
+		k.logger.Debug(k.styles().Success.Render("GET"), key, value)
 		return value, nil
 	}
 	return nil, errors.ErrKeyNotFound
@@ -177,7 +183,7 @@ func (k *KV) GetMany(keys ...string) ([]interface{}, error) {
 			res = append(res, value)
 		}
 	}
-	logger.Debug(theme.AccentGreen.Render("GET"), "result", res)
+	k.logger.Debug(k.styles().Success.Render("GET"), "result", res)
 	return res, nil
 }
 
@@ -194,7 +200,7 @@ func (k *KV) Remove(key string) error {
 	k.mux.Lock()
 	defer k.mux.Unlock()
 	k.data.Remove(key)
-	logger.Debug(theme.AccentRed.Render("DELETE"), key)
+	k.logger.Debug(k.styles().Error.Render("DELETE"), key)
 	return nil
 }
 
@@ -225,7 +231,7 @@ func (k *KV) Clear() error {
 	k.mux.Lock()
 	defer k.mux.Unlock()
 	k.data.Clear()
-	logger.Warn(color.Bold.Sprint("CLEARED TABLE"))
+	k.logger.Warn(k.styles().Title.Render("CLEARED TABLE"))
 	return nil
 }
 
@@ -250,13 +256,13 @@ func (k *KV) handleGetKey(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 	res, err := k.Get(params["key"])
 	if err != nil {
-		logger.Error("GET ERROR", "err", err)
+		k.logger.Error("GET ERROR", "err", err)
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
 	}
 	payload, err := kvResult(map[string]interface{}{params["key"]: res})
 	if err != nil {
-		logger.Error("GET ERROR", "err", err)
+		k.logger.Error("GET ERROR", "err", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -271,13 +277,13 @@ func (k *KV) handleSetKey(w http.ResponseWriter, r *http.Request) {
 
 	err := k.Set(params["key"], params["value"])
 	if err != nil {
-		logger.Error("SET ERROR", "err", err)
+		k.logger.Error("SET ERROR", "err", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 	payload, err := kvResult(map[string]interface{}{params["key"]: params["value"]})
 	if err != nil {
-		logger.Error("SET ERROR", "err", err)
+		k.logger.Error("SET ERROR", "err", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -292,7 +298,7 @@ func (k *KV) handleRemoveKey(w http.ResponseWriter, r *http.Request) {
 	k.Remove(p["key"])
 	payload, err := kvResult(fmt.Sprintf("DELETED %s", p["key"]))
 	if err != nil {
-		logger.Error("DELETE ERROR", "err", err)
+		k.logger.Error("DELETE ERROR", "err", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -303,10 +309,10 @@ func (k *KV) handleRemoveKey(w http.ResponseWriter, r *http.Request) {
 
 // handleKvData processes HTTP GET requests for retrieving all key-value pairs.
 func (k *KV) handleGetKv(w http.ResponseWriter, _ *http.Request) {
-	logger.WithPrefix("ADMIN").Info("GET KV")
+	k.logger.WithPrefix("ADMIN").Info("GET KV")
 	payload, err := k.data.MarshalJSON()
 	if err != nil {
-		logger.Error("DELETE ERROR", "err", err)
+		k.logger.Error("DELETE ERROR", "err", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -319,15 +325,15 @@ func (k *KV) handleGetKv(w http.ResponseWriter, _ *http.Request) {
 func (k *KV) handleClearKv(w http.ResponseWriter, _ *http.Request) {
 	err := k.Clear()
 	if err != nil {
-		logger.Error("CLEAR ERROR", "err", err)
+		k.logger.Error("CLEAR ERROR", "err", err)
 
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	logger.WithPrefix("ADMIN").Warn("CLEARED TABLE")
+	k.logger.WithPrefix("ADMIN").Warn("CLEARED TABLE")
 	payload, err := kvResult("CLEARED TABLE")
 	if err != nil {
-		logger.Error("DELETE ERROR", "err", err)
+		k.logger.Error("DELETE ERROR", "err", err)
 		w.WriteHeader(http.StatusBadRequest)
 	}
 
@@ -339,7 +345,7 @@ func (k *KV) handleClearKv(w http.ResponseWriter, _ *http.Request) {
 func (k *KV) handleGetSize(w http.ResponseWriter, _ *http.Request) {
 	payload, err := kvResult(map[string]interface{}{"size": k.Size()})
 	if err != nil {
-		logger.Error("ERROR", "err", err)
+		k.logger.Error("ERROR", "err", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 	}
 
@@ -350,7 +356,7 @@ func (k *KV) handleGetSize(w http.ResponseWriter, _ *http.Request) {
 func (k *KV) handleJSON(w http.ResponseWriter, r *http.Request) {
 	data, err := sjson.NewFromReader(r.Body)
 	if err != nil {
-		logger.Error(err.Error())
+		k.logger.Error(err.Error())
 	}
 	inserted := sjson.New()
 	if v, err := data.Map(); err == nil {
@@ -369,13 +375,13 @@ func (k *KV) handleJSON(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	} else {
-		logger.Error("ERROR", "err", err)
+		k.logger.Error("ERROR", "err", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 	}
 
 	payload, err := kvResult(inserted.Interface())
 	if err != nil {
-		logger.Error("ERROR", "err", err)
+		k.logger.Error("ERROR", "err", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 	}
 
@@ -391,7 +397,7 @@ func (k *KV) handleBatch(w http.ResponseWriter, r *http.Request) {
 	case "POST":
 		data, err := sjson.NewFromReader(r.Body)
 		if err != nil {
-			logger.Error("ERROR", "err", err)
+			k.logger.Error("ERROR", "err", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		}
 
@@ -399,12 +405,12 @@ func (k *KV) handleBatch(w http.ResponseWriter, r *http.Request) {
 
 			b, err := data.MarshalJSON()
 			if err != nil {
-				logger.Error("ERROR", "err", err)
+				k.logger.Error("ERROR", "err", err)
 				http.Error(w, err.Error(), http.StatusBadRequest)
 			}
 			k.data.FromJSON(b)
 			inserted.UnmarshalJSON(b)
-			logger.Debug(inserted.Interface())
+			k.logger.Debug(inserted.Interface())
 
 		} else if v, err := data.Array(); err == nil {
 			keyvals := make([]interface{}, 0)
@@ -419,12 +425,12 @@ func (k *KV) handleBatch(w http.ResponseWriter, r *http.Request) {
 			}
 
 		} else {
-			logger.Error("ERROR", "err", err)
+			k.logger.Error("ERROR", "err", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		}
 		payload, err := kvResult(inserted.Interface())
 		if err != nil {
-			logger.Error("ERROR", "err", err)
+			k.logger.Error("ERROR", "err", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -433,7 +439,7 @@ func (k *KV) handleBatch(w http.ResponseWriter, r *http.Request) {
 	case "GET":
 		payload, err := kvResult(k.batch)
 		if err != nil {
-			logger.Error("ERROR", "err", err)
+			k.logger.Error("ERROR", "err", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -450,7 +456,7 @@ func (k *KV) AuthMiddleware(_ *mux.Router) mux.MiddlewareFunc {
 			token := r.Header.Get("Authorization")
 			expected := fmt.Sprintf("Bearer %s", k.token)
 			if token == "" || token != expected {
-				logger.Warn("Unauthorized request", "path", r.URL.Path)
+				k.logger.Warn("Unauthorized request", "path", r.URL.Path)
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
@@ -478,9 +484,9 @@ func (k *KV) Serve(port int) error {
 	r.HandleFunc("/adm/kv", k.handleClearKv).Methods("DELETE")
 	r.HandleFunc("/adm/size", k.handleGetSize).Methods("GET")
 	r.Use(k.AuthMiddleware(r))
-	fmt.Printf("%s\n\n", theme.Accent.Render(banner))
-	logger.Debug("Server started 🎉", "address", k.address, "port", port, "auth", k.auth)
-	logger.Fatal(http.ListenAndServe(strings.Join([]string{k.address, strconv.Itoa(port)}, ":"), r))
+	fmt.Printf("%s\n\n", k.styles().Accent.Render(banner))
+	k.logger.Debug("Server started 🎉", "address", k.address, "port", port, "auth", k.auth)
+	k.logger.Fatal(http.ListenAndServe(strings.Join([]string{k.address, strconv.Itoa(port)}, ":"), r))
 
 	return nil
 }
@@ -0,0 +1,35 @@
+package kv
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/log"
+	"github.com/stelmanjones/termtools/theme"
+)
+
+// styles returns the theme of the KV store, which defaults to theme.Current.
+func (k *KV) styles() *theme.Theme {
+	if k.theme == nil {
+		return theme.Current()
+	}
+	return k.theme
+}
+
+// logStyles returns the styles of the log output for t.
+func logStyles(t *theme.Theme) *log.Styles {
+	level := func(l log.Level, s theme.Style) lipgloss.Style {
+		return s.Lipgloss().SetString(strings.ToUpper(l.String())).MaxWidth(4)
+	}
+
+	styles := log.DefaultStyles()
+	styles.Levels[log.DebugLevel] = level(log.DebugLevel, t.Muted)
+	styles.Levels[log.InfoLevel] = level(log.InfoLevel, t.Info)
+	styles.Levels[log.WarnLevel] = level(log.WarnLevel, t.Warning)
+	styles.Levels[log.ErrorLevel] = level(log.ErrorLevel, t.Error)
+	styles.Levels[log.FatalLevel] = level(log.FatalLevel, t.Error)
+	styles.Prefix = t.Title.Lipgloss()
+	styles.Timestamp = t.Muted.Lipgloss()
+	styles.Key = t.Accent.Lipgloss()
+	return styles
+}
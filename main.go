@@ -16,6 +16,13 @@ var logger = log.NewWithOptions(os.Stderr, log.Options{
 })
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "spin" {
+		if err := spinCommand(os.Args[2:]); err != nil {
+			logger.Fatal(err)
+		}
+		return
+	}
+
 	// done := make(chan struct{}, 1)
 	// k := make(chan keys.Key, 1)
 	// ctx := context.Background()
@@ -1,20 +1,37 @@
 package progress
 
 import (
-	"bytes"
 	"fmt"
+	"math"
 	"os"
+	"strings"
 	"sync"
+	"text/template"
 	"time"
 
+	"github.com/stelmanjones/termtools/text"
+	"github.com/stelmanjones/termtools/theme"
 	"golang.org/x/term"
 )
 
 var (
-	Head      byte = '>'
-	Filler    byte = '='
-	Empty     byte = '-'
-	Delimiter byte = '|'
+	Head      = StyleASCII.Head
+	Filler    = StyleASCII.Fill
+	Empty     = StyleASCII.Empty
+	Delimiter = StyleASCII.Delimiter
+)
+
+const (
+	// defaultWidth is the width of a line when it is not set and stdout is not a terminal.
+	defaultWidth = 80
+	// minBarWidth is the narrowest the bar gets to make room for the rest of the layout.
+	minBarWidth = 10
+	// rateWindow is the time over which the rate of a bar is smoothed.
+	rateWindow = 5 * time.Second
+	// sampleInterval is the shortest time between two samples of the rate.
+	sampleInterval = 100 * time.Millisecond
+	// bounceStep is how long the block of an indeterminate bar stays in a cell.
+	bounceStep = 50 * time.Millisecond
 )
 
 type Bar struct {
@@ -23,16 +40,25 @@ type Bar struct {
 	MarginLeft  int
 	current     int
 	elapsed     time.Duration
+	// Width is the width of the whole line including its margins. The bar
+	// takes what the rest of the layout leaves. Zero uses the width of the terminal.
 	Width       int
 	total       int
 	MarginRight int
-	showPercent bool
-	Bar         byte
-	Empty       byte
-	Delimiter   byte
-	Head        byte
-	showElapsed bool
-	showSteps   bool
+	Bar         string
+	Empty       string
+	Delimiter   string
+	Head        string
+	Partials    []string // glyphs of a partly filled cell, see Style
+	theme       *theme.Theme
+	prefix      string
+	tmpl        *template.Template
+	units       Units
+	rate        float64   // smoothed items per second, zero until sampled
+	sampled     time.Time // when the rate was last sampled
+	sampledAt   int       // the count at the last sample
+	finished    bool
+	remove      bool // remove the bar from its Progress once it is finished
 }
 
 func NewBar(total int) *Bar {
@@ -44,27 +70,99 @@ func NewBar(total int) *Bar {
 		Delimiter:   Delimiter,
 		MarginLeft:  1,
 		MarginRight: 1,
-		Width: func() int {
-			w, _, _ := term.GetSize(int(os.Stdout.Fd()))
-			return w
-		}(),
 
 		total:   total,
 		current: 0,
 		elapsed: 0,
 		mtx:     &sync.RWMutex{},
+		tmpl:    defaultLayout,
+	}
+}
+
+// SetStyle sets the glyphs the bar is drawn with, such as StyleBlocks.
+func (b *Bar) SetStyle(s Style) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.Bar, b.Head, b.Empty, b.Delimiter = s.Fill, s.Head, s.Empty, s.Delimiter
+	b.Partials = s.Partials
+}
+
+// style returns the glyphs of the bar. It is called with mtx held.
+func (b *Bar) style() Style {
+	return Style{Fill: b.Bar, Head: b.Head, Empty: b.Empty, Delimiter: b.Delimiter, Partials: b.Partials}
+}
+
+// SetTheme sets the theme the filled part of the bar is colored with,
+// replacing theme.Current.
+func (b *Bar) SetTheme(t *theme.Theme) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.theme = t
+}
+
+// SetPrefix sets the text shown in front of the bar.
+func (b *Bar) SetPrefix(prefix string) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.prefix = prefix
+}
+
+// SetTemplate sets the layout of the bar as a text/template over Stats, for example
+//
+//	{{.Prefix}} {{.Bar}} {{.Percent}} ETA {{.ETA}}
+//
+// The bar is sized to fill the width left by the rest of the layout.
+func (b *Bar) SetTemplate(layout string) error {
+	tmpl, err := template.New("bar").Parse(layout)
+	if err != nil {
+		return err
 	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.tmpl = tmpl
+	return nil
+}
+
+// SetUnits sets how the counts and the rate of the bar are formatted.
+func (b *Bar) SetUnits(u Units) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.units = u
+}
+
+// advance sets the count of the bar to current, starting the clock on the
+// first change and sampling the rate. It is called with mtx held.
+func (b *Bar) advance(current int) {
+	now := time.Now()
+	if b.TimeStarted.IsZero() {
+		b.TimeStarted = now
+		b.sampled = now
+	}
+	b.current = current
+	b.elapsed = now.Sub(b.TimeStarted)
+
+	dt := now.Sub(b.sampled)
+	if dt < sampleInterval {
+		return
+	}
+	rate := float64(b.current-b.sampledAt) / dt.Seconds()
+	if b.rate == 0 {
+		b.rate = rate
+	} else {
+		b.rate += smoothing(dt) * (rate - b.rate)
+	}
+	b.sampled, b.sampledAt = now, b.current
 }
 
 func (p *Bar) Increment() error {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
-	if p.current >= p.total {
+	if p.total > 0 && p.current >= p.total {
 		return ErrTotalReached
 	}
 
-	p.current++
+	p.advance(p.current + 1)
 	return nil
 }
 
@@ -72,22 +170,60 @@ func (p *Bar) Done() error {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
-	if p.current < p.total {
+	if !p.complete() {
 		return ErrNotDone
 	}
 
 	return nil
 }
 
+// Finish marks the bar as finished. An indeterminate bar takes its count as
+// its total, so it shows as complete.
+func (b *Bar) Finish() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.total <= 0 {
+		b.total = b.current
+	}
+	b.finished = true
+}
+
+// Finished reports whether Finish was called or the bar reached its total.
+func (b *Bar) Finished() bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.complete()
+}
+
+// complete is Finished with mtx held.
+func (b *Bar) complete() bool {
+	return b.finished || (b.total > 0 && b.current >= b.total)
+}
+
+// SetRemoveOnFinish sets whether the bar disappears from its Progress once it
+// is finished, instead of staying as it was last drawn.
+func (b *Bar) SetRemoveOnFinish(remove bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.remove = remove
+}
+
+// removable reports whether the bar is finished and should disappear.
+func (b *Bar) removable() bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.remove && b.complete()
+}
+
 func (b *Bar) Set(current int) error {
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
 
-	if current < 0 || current > b.total {
+	if current < 0 || (b.total > 0 && current > b.total) {
 		return ErrInvalidValue
 	}
 
-	b.current = current
+	b.advance(current)
 	return nil
 }
 
@@ -99,15 +235,47 @@ func (b *Bar) Incr() bool {
 		return false
 	}
 
-	var t time.Time
-	if b.TimeStarted.IsZero() || b.TimeStarted.Equal(t) {
-		b.TimeStarted = time.Now()
+	b.advance(b.current + 1)
+	return true
+}
+
+// Add adds n to the progress of the bar. Progress beyond the total is capped at it.
+func (b *Bar) Add(n int) {
+	if n == 0 {
+		return
+	}
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	current := max(b.current+n, 0)
+	if b.total > 0 {
+		current = min(current, b.total)
+	}
+	b.advance(current)
+}
+
+// SetTotal sets the total of the bar. Zero or less makes the bar
+// indeterminate, for work of unknown size.
+func (b *Bar) SetTotal(total int) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.total = max(total, 0)
+	if b.total > 0 {
+		b.current = min(b.current, b.total)
 	}
+}
 
-	b.elapsed = time.Since(b.TimeStarted)
+// Total returns the total of the bar, zero if it is indeterminate.
+func (b *Bar) Total() int {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.total
+}
 
-	b.current++
-	return true
+// Indeterminate reports whether the total of the bar is unknown. An
+// indeterminate bar shows a bouncing block instead of how much is done.
+func (b *Bar) Indeterminate() bool {
+	return b.Total() <= 0
 }
 
 // Current returns the current progress of the bar
@@ -119,7 +287,20 @@ func (b *Bar) Current() int {
 
 // CompletedPercent return the percent completed
 func (b *Bar) CompletedPercent() float64 {
-	return (float64(b.Current()) / float64(b.total)) * 100.00
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.percent()
+}
+
+// percent returns the percent completed. It is called with mtx held.
+func (b *Bar) percent() float64 {
+	if b.total <= 0 {
+		if b.finished {
+			return 100
+		}
+		return 0
+	}
+	return (float64(b.current) / float64(b.total)) * 100.00
 }
 
 // CompletedPercentString returns the formatted string representation of the completed percent
@@ -134,31 +315,168 @@ func (b *Bar) TimeElapsed() time.Duration {
 	return b.elapsed
 }
 
-// Bytes returns the byte presentation of the progress bar
-func (b *Bar) Bytes() []byte {
-	completedWidth := int(float64(b.Width) * (b.CompletedPercent() / 100.00))
+// Rate returns the smoothed number of items, or bytes, per second.
+func (b *Bar) Rate() float64 {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.currentRate()
+}
 
-	// add fill and empty bits
-	var buf bytes.Buffer
-	for i := 0; i < completedWidth; i++ {
-		buf.WriteByte(b.Bar)
+// currentRate returns the smoothed rate. If no update arrived since the last
+// sample, the time without progress is blended in, so the rate drops while
+// the bar stalls. Once the bar is complete the rate is frozen. It is called
+// with mtx held.
+func (b *Bar) currentRate() float64 {
+	if b.TimeStarted.IsZero() {
+		return 0
+	}
+	if b.complete() {
+		if b.rate == 0 && b.elapsed > 0 {
+			return float64(b.current) / b.elapsed.Seconds()
+		}
+		return b.rate
 	}
-	for i := 0; i < b.Width-completedWidth; i++ {
-		buf.WriteByte(b.Empty)
+	dt := time.Since(b.sampled)
+	if dt < sampleInterval {
+		return b.rate
 	}
+	pending := float64(b.current-b.sampledAt) / dt.Seconds()
+	if b.rate == 0 {
+		return pending
+	}
+	return b.rate + smoothing(dt)*(pending-b.rate)
+}
 
-	// set head bit
-	pb := buf.Bytes()
-	if completedWidth > 0 && completedWidth < b.Width {
-		pb[completedWidth-1] = b.Head
+// smoothing returns the weight of a sample that covers dt.
+func smoothing(dt time.Duration) float64 {
+	return 1 - math.Exp(-dt.Seconds()/rateWindow.Seconds())
+}
+
+// ETA returns the estimated time left, or false if there is no rate yet.
+func (b *Bar) ETA() (time.Duration, bool) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.eta()
+}
+
+// eta is ETA with mtx held.
+func (b *Bar) eta() (time.Duration, bool) {
+	if b.complete() {
+		return 0, true
+	}
+	rate := b.currentRate()
+	if rate <= 0 {
+		return 0, false
 	}
+	left := float64(b.total-b.current) / rate
+	return time.Duration(left * float64(time.Second)), true
+}
+
+// Stats returns the values shown by the layout of the bar, except the bar itself.
+func (b *Bar) Stats() Stats {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.stats()
+}
 
-	// set left and right ends bits
-	pb[0], pb[len(pb)-1] = b.Delimiter, b.Delimiter
+// stats is Stats with mtx held.
+func (b *Bar) stats() Stats {
+	s := Stats{
+		Prefix:  b.prefix,
+		Percent: fmt.Sprintf("%3.f%%", b.percent()),
+		Current: b.units.format(float64(b.current)),
+		Total:   b.units.format(float64(b.total)),
+		Rate:    b.units.format(b.currentRate()),
+		ETA:     "--",
+		Elapsed: b.elapsed.Round(time.Second).String(),
+	}
+	if b.total <= 0 && !b.finished {
+		s.Percent, s.Total = "  ?%", "?"
+		return s
+	}
+	if eta, ok := b.eta(); ok {
+		s.ETA = formatETA(eta)
+	}
+	return s
+}
+
+// lineWidth returns the width of a line without its margins. Unless the bar
+// sets its own width, lines are width cells wide, or as wide as the terminal
+// if width is zero.
+func (b *Bar) lineWidth(width int) int {
+	if b.Width > 0 {
+		width = b.Width
+	}
+	if width <= 0 {
+		width = defaultWidth
+		if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+			width = w
+		}
+	}
+	return width - max(b.MarginLeft, 0) - max(b.MarginRight, 0)
+}
+
+// render renders the bar itself with the given width. It is called with mtx held.
+func (b *Bar) render(width int) string {
+	if b.total <= 0 && !b.finished {
+		return b.renderIndeterminate(width)
+	}
+	st, t := b.style(), b.styles()
+	inner := max(width-2*text.VisibleLength(st.Delimiter), 0)
+	n := st.cells(inner)
 
-	return pb
+	fill := st.fill(n, b.percent()/100)
+	return st.Delimiter +
+		paint(t, fill, n) +
+		t.Muted.Render(strings.Repeat(st.Empty, n-len(fill))) +
+		strings.Repeat(" ", inner-n*max(text.VisibleLength(st.Fill), 1)) +
+		st.Delimiter
+}
+
+// renderIndeterminate renders a block that bounces between the ends of the
+// bar as time passes. It is called with mtx held.
+func (b *Bar) renderIndeterminate(width int) string {
+	st, t := b.style(), b.styles()
+	inner := max(width-2*text.VisibleLength(st.Delimiter), 0)
+	n := max(st.cells(inner), 1)
+	block := max(n/5, 1)
+	span := max(n-block, 1)
+
+	var step int
+	if !b.TimeStarted.IsZero() {
+		step = int(time.Since(b.TimeStarted) / bounceStep)
+	}
+	pos := step % (2 * span)
+	if pos > span {
+		pos = 2*span - pos
+	}
+	block = min(block, n-pos)
+
+	return st.Delimiter +
+		t.Muted.Render(strings.Repeat(st.Empty, pos)) +
+		t.Progress.Render(strings.Repeat(st.Fill, block)) +
+		t.Muted.Render(strings.Repeat(st.Empty, n-pos-block)) +
+		strings.Repeat(" ", max(inner-n*max(text.VisibleLength(st.Fill), 1), 0)) +
+		st.Delimiter
+}
+
+// Bytes returns the byte presentation of the progress bar, laid out by its
+// template and fitted to its width.
+func (b *Bar) Bytes() []byte {
+	return []byte(b.String())
 }
 
 func (b *Bar) String() string {
-	return string(b.Bytes())
+	return b.line(0)
+}
+
+// line lays out the bar on a line of width cells, see lineWidth.
+func (b *Bar) line(width int) string {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	line, err := layout(b.tmpl, b.stats(), b.lineWidth(width), b.render)
+	if err != nil {
+		line = err.Error()
+	}
+	return strings.Repeat(" ", max(b.MarginLeft, 0)) + line
 }
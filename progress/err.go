@@ -12,5 +12,9 @@ var (
 	ErrNotDone      = errors.New("not done")
 	ErrInvalidWidth = errors.New("invalid width")
 	ErrInvalidValue = errors.New("invalid value")
+	// ErrUnknownBar is returned when a bar is not in the Progress it is moved in.
+	ErrUnknownBar = errors.New("unknown bar")
+	// ErrRunning is returned by Run when the Progress already renders.
+	ErrRunning = errors.New("progress is running")
 )
 
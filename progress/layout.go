@@ -0,0 +1,120 @@
+package progress
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/muesli/reflow/truncate"
+	"github.com/stelmanjones/termtools/text"
+)
+
+// DefaultTemplate is the layout of a bar unless it is given one with SetTemplate.
+const DefaultTemplate = "{{.Prefix}} {{.Bar}} {{.Percent}} {{.Current}}/{{.Total}} {{.Rate}}/s ETA {{.ETA}}"
+
+// defaultLayout is DefaultTemplate parsed.
+var defaultLayout = template.Must(template.New("bar").Parse(DefaultTemplate))
+
+// Stats are the values a layout template can show. All of them are formatted
+// already, counts in the units of the bar.
+type Stats struct {
+	Prefix  string
+	Bar     string // the bar itself, sized to fill the width left by the rest of the layout
+	Percent string
+	Current string
+	Total   string
+	Rate    string // per second, smoothed
+	ETA     string // "--" until there is a rate
+	Elapsed string
+}
+
+// Units selects how the counts of a bar are formatted.
+type Units int
+
+const (
+	// UnitsNone formats counts as plain numbers.
+	UnitsNone Units = iota
+	// UnitsBytes formats counts as bytes with binary prefixes, such as 1.5 MiB.
+	UnitsBytes
+)
+
+// format formats n in units u.
+func (u Units) format(n float64) string {
+	if u == UnitsBytes {
+		return FormatBytes(n)
+	}
+	if n == math.Trunc(n) || n >= 100 {
+		return strconv.FormatFloat(n, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(n, 'f', 1, 64)
+}
+
+// FormatBytes formats a number of bytes with binary prefixes, such as 1.5 MiB.
+func FormatBytes(n float64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%.0f B", n)
+	}
+	exp := 0
+	for n >= unit && exp < 6 {
+		n /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", n, "KMGTPE"[exp-1])
+}
+
+// formatETA formats the time left, rounded to seconds.
+func formatETA(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
+// barMarker stands in for the bar while the width of the rest of the layout is measured.
+const barMarker = "\x00"
+
+// layout renders stats with tmpl into a line of width cells. The bar is
+// rendered by bar with the width that is left, but never narrower than
+// minBarWidth. If the rest of the line leaves less than that, the prefix is
+// shortened, and if that is not enough the line is cut at width.
+func layout(tmpl *template.Template, stats Stats, width int, bar func(width int) string) (string, error) {
+	stats.Bar = barMarker
+	line, rest, err := execute(tmpl, stats)
+	if err != nil {
+		return "", err
+	}
+	if !strings.Contains(line, barMarker) {
+		return truncate.String(line, uint(max(width, 0))), nil
+	}
+	if over := rest + minBarWidth - width; over > 0 && stats.Prefix != "" {
+		stats.Prefix = shorten(stats.Prefix, text.VisibleLength(stats.Prefix)-over)
+		if line, rest, err = execute(tmpl, stats); err != nil {
+			return "", err
+		}
+	}
+	line = strings.Replace(line, barMarker, bar(max(width-rest, minBarWidth)), 1)
+	if rest+minBarWidth > width {
+		line = truncate.String(line, uint(max(width, 0)))
+	}
+	return line, nil
+}
+
+// execute renders stats with tmpl and returns the line and the width of the
+// line without the bar.
+func execute(tmpl *template.Template, stats Stats) (string, int, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, stats); err != nil {
+		return "", 0, err
+	}
+	line := strings.TrimSpace(sb.String())
+	return line, text.VisibleLength(strings.Replace(line, barMarker, "", 1)), nil
+}
+
+// shorten cuts s to width cells, ending it with an ellipsis.
+func shorten(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return truncate.StringWithTail(s, uint(width), "…")
+}
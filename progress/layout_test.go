@@ -0,0 +1,41 @@
+package progress_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stelmanjones/termtools/progress"
+	"github.com/stelmanjones/termtools/text"
+)
+
+func TestBarFitsWidth(t *testing.T) {
+	prefix := strings.Repeat("p", 35)
+	for _, width := range []int{80, 40, 25, 12, 5} {
+		for _, units := range []progress.Units{progress.UnitsNone, progress.UnitsBytes} {
+			bar := progress.NewBar(3 << 20)
+			bar.Width = width
+			bar.SetPrefix(prefix)
+			bar.SetUnits(units)
+			bar.Add(1 << 20)
+
+			line := bar.String()
+			if n := text.VisibleLength(line); n > width {
+				t.Errorf("width %d, units %d: line is %d cells wide: %q", width, units, n, line)
+			}
+		}
+	}
+}
+
+func TestBarShortensPrefix(t *testing.T) {
+	bar := progress.NewBar(10)
+	bar.Width = 40
+	bar.SetPrefix(strings.Repeat("p", 35))
+
+	line := bar.String()
+	if !strings.Contains(line, "…") {
+		t.Errorf("prefix was not shortened: %q", line)
+	}
+	if !strings.Contains(line, "0%") {
+		t.Errorf("percent was dropped: %q", line)
+	}
+}
@@ -1,14 +1,14 @@
 package progress
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"slices"
+	"strings"
 	"sync"
 	"time"
-
-	"github.com/gosuri/uilive"
-	"golang.org/x/term"
 )
 
 var (
@@ -18,35 +18,28 @@ var (
 	defaultProgress               = New()
 )
 
+// Progress renders a list of bars below each other. Bars can be added,
+// removed and moved while it renders; its methods and those of its bars are
+// safe to call from any goroutine.
 type Progress struct {
-	Out         io.Writer
-	mtx         *sync.RWMutex
-	done        chan bool
-	live        *uilive.Writer
-	Bars        []*Bar
+	Out  io.Writer
+	mtx  *sync.RWMutex
+	bars []*Bar
+	// Width is the width of the lines of bars that don't set their own. Zero
+	// uses the width of the terminal.
 	Width       int
 	RefreshRate time.Duration
-	current     int
-	total       int
-	elapsed     time.Duration
-	showSteps   bool
-	showPercent bool
-	showElapsed bool
+	cancel      context.CancelFunc // stops the render loop, nil unless it runs
+	stopped     chan struct{}      // closed once the render loop returned
+	lines       int                // lines drawn by the last render, erased by the next
 }
 
 func New() *Progress {
-	live := uilive.New()
-	live.Out = Stdout
 	return &Progress{
-		Bars:        make([]*Bar, 0),
+		Out:         Stdout,
+		bars:        make([]*Bar, 0),
 		RefreshRate: RefreshRate,
-		Width: func() int {
-			w, _, _ := term.GetSize(int(os.Stdout.Fd()))
-			return w
-		}(),
-		live: live,
-		done: make(chan bool),
-		mtx:  &sync.RWMutex{},
+		mtx:         &sync.RWMutex{},
 	}
 }
 
@@ -65,27 +58,93 @@ func Listen() {
 	defaultProgress.Listen()
 }
 
+// Wait waits for the bars of the default progress to finish and stops it.
+func Wait() {
+	defaultProgress.Wait()
+}
+
 // Add adds a new bar to the default progress bar
 func Add(total int) *Bar {
 	return defaultProgress.Add(total)
 }
 
+// AddLabeled adds a new bar with a label to the default progress.
+func AddLabeled(label string, total int) *Bar {
+	return defaultProgress.AddLabeled(label, total)
+}
+
+// Remove removes a bar from the default progress.
+func Remove(bar *Bar) bool {
+	return defaultProgress.Remove(bar)
+}
+
 // Add adds a new bar to the progress bar
 func (p *Progress) Add(total int) *Bar {
 	bar := NewBar(total)
-	p.mtx.Lock()
-	defaultProgress.Bars = append(defaultProgress.Bars, bar)
-	defaultProgress.mtx.Unlock()
+	p.Append(bar)
+	return bar
+}
+
+// AddLabeled adds a new bar whose prefix is label.
+func (p *Progress) AddLabeled(label string, total int) *Bar {
+	bar := NewBar(total)
+	bar.SetPrefix(label)
+	p.Append(bar)
 	return bar
 }
 
+// Append adds existing bars below the bars of the progress. A bar that is
+// already in the progress is not added twice.
+func (p *Progress) Append(bars ...*Bar) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	for _, bar := range bars {
+		if !slices.Contains(p.bars, bar) {
+			p.bars = append(p.bars, bar)
+		}
+	}
+}
+
+// Remove removes a bar from the progress. It returns false if the bar is not in it.
+func (p *Progress) Remove(bar *Bar) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	i := slices.Index(p.bars, bar)
+	if i < 0 {
+		return false
+	}
+	p.bars = slices.Delete(p.bars, i, i+1)
+	return true
+}
+
+// Move moves a bar to index, shifting the bars from there down. An index
+// beyond either end moves the bar to that end.
+func (p *Progress) Move(bar *Bar, index int) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	i := slices.Index(p.bars, bar)
+	if i < 0 {
+		return ErrUnknownBar
+	}
+	p.bars = slices.Delete(p.bars, i, i+1)
+	index = min(max(index, 0), len(p.bars))
+	p.bars = slices.Insert(p.bars, index, bar)
+	return nil
+}
+
+// Bars returns the bars of the progress in the order they are drawn.
+func (p *Progress) Bars() []*Bar {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	return slices.Clone(p.bars)
+}
+
 // SetOut sets the output writer for the progress bar
 func (p *Progress) SetOut(o io.Writer) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
 	p.Out = o
-	p.live.Out = o
 }
 
 // SetRefreshRate sets the refresh rate for the progress bar
@@ -95,46 +154,153 @@ func (p *Progress) SetRefreshRate(interval time.Duration) {
 	p.RefreshRate = interval
 }
 
+// print draws the bars over the lines of the last render, dropping finished
+// bars that should disappear first.
 func (p *Progress) print() {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
-	for _, bar := range p.Bars {
-		fmt.Fprintln(p.live, bar.String())
+	p.bars = slices.DeleteFunc(p.bars, (*Bar).removable)
+
+	var sb strings.Builder
+	p.erase(&sb)
+	for _, bar := range p.bars {
+		sb.WriteString(bar.line(p.Width))
+		sb.WriteByte('\n')
 	}
-	p.live.Flush()
+	p.lines = len(p.bars)
+	_, _ = io.WriteString(p.Out, sb.String())
 }
 
-// Listen listens for updates and renders the progress bars
-func (p *Progress) Listen() {
-	for {
+// erase moves the cursor up to the first line of the last render and clears
+// the screen from there. It is called with mtx held.
+func (p *Progress) erase(sb *strings.Builder) {
+	if p.lines > 0 {
+		fmt.Fprintf(sb, "\033[%dF\033[J", p.lines)
+	}
+	p.lines = 0
+}
 
+// Run renders the bars every RefreshRate until ctx is done or Stop is
+// called, and renders them a last time before it returns. It returns the
+// error of ctx if that ended it, or ErrRunning if the progress already renders.
+func (p *Progress) Run(ctx context.Context) error {
+	inner, err := p.begin(ctx)
+	if err != nil {
+		return err
+	}
+	p.loop(inner)
+	return ctx.Err()
+}
+
+// begin registers a render loop under ctx, so Stop can end it.
+func (p *Progress) begin(ctx context.Context) (context.Context, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.cancel != nil {
+		return nil, ErrRunning
+	}
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.stopped = make(chan struct{})
+	return ctx, nil
+}
+
+// loop renders the bars until ctx is done.
+func (p *Progress) loop(ctx context.Context) {
+	defer func() {
+		p.print()
 		p.mtx.Lock()
-		interval := p.RefreshRate
+		p.cancel()
+		p.cancel = nil
+		close(p.stopped)
 		p.mtx.Unlock()
+	}()
 
+	for {
+		p.print()
+
+		p.mtx.RLock()
+		interval := p.RefreshRate
+		p.mtx.RUnlock()
+
+		timer := time.NewTimer(interval)
 		select {
-		case <-time.After(interval):
-			p.print()
-		case <-p.done:
-			p.print()
-			close(p.done)
+		case <-ctx.Done():
+			timer.Stop()
 			return
+		case <-timer.C:
 		}
 	}
 }
 
+// Listen listens for updates and renders the progress bars until Stop is called.
+func (p *Progress) Listen() {
+	_ = p.Run(context.Background())
+}
+
 // Start starts the rendering the progress of progress bars. It listens for updates using `bar.Set(n)` and new bars when added using `AddBar`
 func (p *Progress) Start() {
-	go p.Listen()
+	p.StartContext(context.Background())
 }
 
-// Stop stops listening
+// StartContext starts rendering the bars in the background until ctx is
+// done or Stop is called. It does nothing if the progress already renders.
+func (p *Progress) StartContext(ctx context.Context) {
+	ctx, err := p.begin(ctx)
+	if err != nil {
+		return
+	}
+	go p.loop(ctx)
+}
+
+// Stop stops rendering and waits for the last render. It does nothing if the
+// progress doesn't render.
 func (p *Progress) Stop() {
-	p.done <- true
-	<-p.done
+	p.mtx.Lock()
+	cancel, stopped := p.cancel, p.stopped
+	p.mtx.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-stopped
+}
+
+// Wait waits until every bar is finished and stops rendering. It returns
+// right away if there are no bars.
+func (p *Progress) Wait() {
+	for {
+		p.mtx.RLock()
+		interval := p.RefreshRate
+		done := !slices.ContainsFunc(p.bars, func(b *Bar) bool { return !b.Finished() })
+		p.mtx.RUnlock()
+		if done {
+			break
+		}
+		time.Sleep(interval)
+	}
+	p.Stop()
+}
+
+// bypass writes to the output of a Progress in place of its bars.
+type bypass struct {
+	p *Progress
+}
+
+// Write erases the bars and writes data. The bars are drawn again below it
+// by the next render.
+func (b bypass) Write(data []byte) (int, error) {
+	p := b.p
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	var sb strings.Builder
+	p.erase(&sb)
+	if _, err := io.WriteString(p.Out, sb.String()); err != nil {
+		return 0, err
+	}
+	return p.Out.Write(data)
 }
 
 // Bypass returns a writer which allows non-buffered data to be written to the underlying output
 func (p *Progress) Bypass() io.Writer {
-	return p.live.Bypass()
+	return bypass{p: p}
 }
@@ -0,0 +1,156 @@
+package progress_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stelmanjones/termtools/progress"
+)
+
+// newProgress returns a progress that renders to a buffer every millisecond.
+func newProgress() (*progress.Progress, *bytes.Buffer) {
+	var out bytes.Buffer
+	p := progress.New()
+	p.SetOut(&out)
+	p.SetRefreshRate(time.Millisecond)
+	p.Width = 60
+	return p, &out
+}
+
+// exercise changes the bars of p and writes through it from several
+// goroutines, while p renders, and finishes every bar it added.
+func exercise(t *testing.T, p *progress.Progress) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bar := p.AddLabeled(fmt.Sprintf("worker %d", i), 50)
+			extra := p.Add(0)
+			for n := 1; n <= 50; n++ {
+				if err := bar.Set(n); err != nil {
+					t.Error(err)
+					return
+				}
+				extra.Add(1)
+				if err := p.Move(bar, n%3); err != nil {
+					t.Error(err)
+					return
+				}
+				if n%10 == 0 {
+					fmt.Fprintf(p.Bypass(), "worker %d at %d\n", i, n)
+				}
+			}
+			extra.Finish()
+			if i%2 == 0 && !p.Remove(extra) {
+				t.Error("bar was not in the progress")
+			}
+			bar.Finish()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestProgressRun(t *testing.T) {
+	p, out := newProgress()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- p.Run(ctx) }()
+
+	exercise(t, p)
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	if !strings.Contains(out.String(), "worker 7 at 50\n") {
+		t.Error("bypassed write is missing from the output")
+	}
+}
+
+func TestProgressStartStop(t *testing.T) {
+	p, _ := newProgress()
+	p.StartContext(context.Background())
+	if err := p.Run(context.Background()); err != progress.ErrRunning {
+		t.Fatalf("got %v, want %v", err, progress.ErrRunning)
+	}
+	exercise(t, p)
+	p.Stop()
+}
+
+func TestProgressWait(t *testing.T) {
+	p, _ := newProgress()
+	p.Start()
+	exercise(t, p)
+	p.Wait()
+	// Run renders once and returns the error of its context if the progress
+	// is stopped
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Run(ctx); err != context.Canceled {
+		t.Fatalf("progress didn't stop: %v", err)
+	}
+}
+
+func TestProgressAddToReceiver(t *testing.T) {
+	p := progress.New()
+	bar := p.Add(10)
+	labeled := p.AddLabeled("files", 10)
+
+	bars := p.Bars()
+	if len(bars) != 2 || bars[0] != bar || bars[1] != labeled {
+		t.Fatalf("got %d bars, want the 2 added", len(bars))
+	}
+	if progress.Remove(bar) || progress.Remove(labeled) {
+		t.Error("bar was added to the default progress")
+	}
+}
+
+func TestProgressStopDoesNotDeadlock(t *testing.T) {
+	p, _ := newProgress()
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		p.Stop() // not rendering
+		p.Start()
+		p.Stop()
+		p.Stop() // stopped already
+
+		p.Start()
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				p.Stop()
+			}()
+		}
+		wg.Wait()
+
+		// Stop does nothing until Run started, so it is called until Run returns
+		done := make(chan error)
+		go func() { done <- p.Run(context.Background()) }()
+		for {
+			p.Stop()
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Errorf("got %v, want nil", err)
+				}
+				return
+			case <-time.After(time.Millisecond):
+			}
+		}
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop deadlocked")
+	}
+}
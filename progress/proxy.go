@@ -0,0 +1,124 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// proxyReader adds the bytes read from r to a bar.
+type proxyReader struct {
+	r   io.Reader
+	bar *Bar
+}
+
+func (p *proxyReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.bar.Add(n)
+	return n, err
+}
+
+// WriteTo keeps the fast path of r for io.Copy, counting what it writes to w.
+func (p *proxyReader) WriteTo(w io.Writer) (int64, error) {
+	if wt, ok := p.r.(io.WriterTo); ok {
+		return wt.WriteTo(&proxyWriter{w: w, bar: p.bar})
+	}
+	return io.Copy(w, struct{ io.Reader }{p})
+}
+
+// Close closes r if it is an io.Closer.
+func (p *proxyReader) Close() error {
+	if c, ok := p.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// proxyWriter adds the bytes written to w to a bar.
+type proxyWriter struct {
+	w   io.Writer
+	bar *Bar
+}
+
+func (p *proxyWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.bar.Add(n)
+	return n, err
+}
+
+// ReadFrom keeps the fast path of w for io.Copy, counting what it reads from r.
+func (p *proxyWriter) ReadFrom(r io.Reader) (int64, error) {
+	if rf, ok := p.w.(io.ReaderFrom); ok {
+		return rf.ReadFrom(struct{ io.Reader }{&proxyReader{r: r, bar: p.bar}})
+	}
+	return io.Copy(struct{ io.Writer }{p}, r)
+}
+
+// Close closes w if it is an io.Closer.
+func (p *proxyWriter) Close() error {
+	if c, ok := p.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// ProxyReader returns a reader that adds the bytes read from r to the bar.
+// io.Copy keeps using the fast path of r if it has one. Close closes r if it
+// is an io.Closer.
+func (b *Bar) ProxyReader(r io.Reader) io.ReadCloser {
+	return &proxyReader{r: r, bar: b}
+}
+
+// ProxyWriter returns a writer that adds the bytes written to w to the bar.
+// io.Copy keeps using the fast path of w if it has one. Close closes w if it
+// is an io.Closer.
+func (b *Bar) ProxyWriter(w io.Writer) io.WriteCloser {
+	return &proxyWriter{w: w, bar: b}
+}
+
+// Copy copies from src to dst like io.Copy while showing a bar of the bytes
+// copied on Stdout. If size is zero or less, the size is unknown and the bar
+// is indeterminate until the copy is done. The bar is finished once the copy
+// succeeds, whatever size was given. If Stdout is not a terminal, only the
+// final line of the bar is printed.
+func Copy(dst io.Writer, src io.Reader, size int64) (int64, error) {
+	bar := NewBar(int(max(size, 0)))
+	bar.SetUnits(UnitsBytes)
+
+	if f, ok := Stdout.(*os.File); !ok || !term.IsTerminal(int(f.Fd())) {
+		n, err := io.Copy(dst, bar.ProxyReader(src))
+		if err == nil {
+			bar.Finish()
+		}
+		fmt.Fprintln(Stdout, bar.String())
+		return n, err
+	}
+
+	done := make(chan struct{})
+	rendered := make(chan struct{})
+	go func() {
+		defer close(rendered)
+		ticker := time.NewTicker(RefreshRate)
+		defer ticker.Stop()
+		for {
+			fmt.Fprint(Stdout, "\r"+bar.String()+"\033[K")
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	n, err := io.Copy(dst, bar.ProxyReader(src))
+	if err == nil {
+		bar.Finish()
+	}
+	close(done)
+	<-rendered
+	fmt.Fprintln(Stdout, "\r"+bar.String()+"\033[K")
+	return n, err
+}
@@ -0,0 +1,161 @@
+package progress_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stelmanjones/termtools/progress"
+)
+
+const data = "the quick brown gopher jumps over the lazy dog"
+
+// writerTo is a reader with a fast path for io.Copy, which records its use.
+type writerTo struct {
+	r    *strings.Reader
+	used bool
+}
+
+func (w *writerTo) Read(b []byte) (int, error) { return w.r.Read(b) }
+
+func (w *writerTo) WriteTo(dst io.Writer) (int64, error) {
+	w.used = true
+	return w.r.WriteTo(dst)
+}
+
+// readerFrom is a writer with a fast path for io.Copy, which records its use.
+type readerFrom struct {
+	buf  bytes.Buffer
+	used bool
+}
+
+func (r *readerFrom) Write(b []byte) (int, error) { return r.buf.Write(b) }
+
+func (r *readerFrom) ReadFrom(src io.Reader) (int64, error) {
+	r.used = true
+	return r.buf.ReadFrom(src)
+}
+
+// onlyReader and onlyWriter hide the fast paths of what they wrap.
+type onlyReader struct{ io.Reader }
+type onlyWriter struct{ io.Writer }
+
+// checkCopied checks that all of data was copied to got and counted by bar.
+func checkCopied(t *testing.T, n int64, err error, got string, bar *progress.Bar) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(data)) || got != data {
+		t.Errorf("copied %d bytes %q, want %q", n, got, data)
+	}
+	if bar.Current() != len(data) {
+		t.Errorf("bar at %d, want %d", bar.Current(), len(data))
+	}
+}
+
+func TestProxyReader(t *testing.T) {
+	var dst bytes.Buffer
+	bar := progress.NewBar(len(data))
+	n, err := io.Copy(onlyWriter{&dst}, bar.ProxyReader(onlyReader{strings.NewReader(data)}))
+	checkCopied(t, n, err, dst.String(), bar)
+}
+
+func TestProxyReaderWriterTo(t *testing.T) {
+	var dst bytes.Buffer
+	src := &writerTo{r: strings.NewReader(data)}
+	bar := progress.NewBar(len(data))
+	n, err := io.Copy(onlyWriter{&dst}, bar.ProxyReader(src))
+	checkCopied(t, n, err, dst.String(), bar)
+	if !src.used {
+		t.Error("WriteTo of the source was not used")
+	}
+}
+
+func TestProxyWriter(t *testing.T) {
+	var dst bytes.Buffer
+	bar := progress.NewBar(len(data))
+	n, err := io.Copy(bar.ProxyWriter(onlyWriter{&dst}), onlyReader{strings.NewReader(data)})
+	checkCopied(t, n, err, dst.String(), bar)
+}
+
+func TestProxyWriterReaderFrom(t *testing.T) {
+	dst := &readerFrom{}
+	bar := progress.NewBar(len(data))
+	n, err := io.Copy(bar.ProxyWriter(dst), onlyReader{strings.NewReader(data)})
+	checkCopied(t, n, err, dst.buf.String(), bar)
+	if !dst.used {
+		t.Error("ReadFrom of the destination was not used")
+	}
+}
+
+// captureStdout sends what Copy draws to a buffer for the rest of the test.
+func captureStdout(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	old := progress.Stdout
+	progress.Stdout = &buf
+	t.Cleanup(func() { progress.Stdout = old })
+	return &buf
+}
+
+// lastLine returns the last line Copy drew.
+func lastLine(out string) string {
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	return lines[len(lines)-1]
+}
+
+func TestCopy(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		data string
+		size int64
+	}{
+		{"known size", data, int64(len(data))},
+		{"unknown size", data, 0},
+		{"zero bytes", "", 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			out := captureStdout(t)
+			var dst bytes.Buffer
+			n, err := progress.Copy(&dst, strings.NewReader(tt.data), tt.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != int64(len(tt.data)) || dst.String() != tt.data {
+				t.Errorf("copied %d bytes %q, want %q", n, dst.String(), tt.data)
+			}
+			if line := lastLine(out.String()); !strings.Contains(line, "100%") {
+				t.Errorf("last line %q doesn't show a finished bar", line)
+			}
+		})
+	}
+}
+
+func TestCopyNotTerminal(t *testing.T) {
+	out := captureStdout(t)
+	if _, err := progress.Copy(io.Discard, strings.NewReader(data), int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); strings.Count(got, "\n") != 1 || strings.Contains(got, "\r") {
+		t.Errorf("got %q, want only the final line", got)
+	}
+}
+
+func TestCopyError(t *testing.T) {
+	out := captureStdout(t)
+	errRead := errors.New("read failed")
+	src := io.MultiReader(strings.NewReader(data), errReader{errRead})
+	if _, err := progress.Copy(io.Discard, src, 2*int64(len(data))); err != errRead {
+		t.Fatalf("got %v, want %v", err, errRead)
+	}
+	if line := lastLine(out.String()); strings.Contains(line, "100%") {
+		t.Errorf("last line %q shows a finished bar", line)
+	}
+}
+
+// errReader fails every read with err.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
@@ -0,0 +1,141 @@
+package progress
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lucasb-eyer/go-colorful"
+	"github.com/stelmanjones/termtools/text"
+	"github.com/stelmanjones/termtools/theme"
+)
+
+// Style is the set of glyphs a bar is drawn with. The glyphs of the filled
+// and the empty part should be as wide as each other.
+type Style struct {
+	Fill      string
+	Head      string // the last filled cell, unless there are Partials
+	Empty     string
+	Delimiter string // drawn at both ends of the bar, may be empty
+	// Partials are the glyphs of a partly filled cell, from the least to the
+	// most filled. They show progress finer than a cell.
+	Partials []string
+}
+
+var (
+	// StyleASCII draws bars with ASCII characters only. It is the default.
+	StyleASCII = Style{Fill: "=", Head: ">", Empty: "-", Delimiter: "|"}
+	// StyleBlocks draws bars with block elements, filling cells in eighths.
+	StyleBlocks = Style{
+		Fill:      "█",
+		Empty:     " ",
+		Delimiter: "│",
+		Partials:  []string{"▏", "▎", "▍", "▌", "▋", "▊", "▉"},
+	}
+	// StyleDots draws bars with braille dots, filling cells dot by dot.
+	StyleDots = Style{
+		Fill:     "⣿",
+		Empty:    "⣀",
+		Partials: []string{"⡀", "⡄", "⡆", "⡇", "⣇", "⣧", "⣷"},
+	}
+	// StylePipe draws bars as a thin line that turns into a thick one.
+	StylePipe = Style{Fill: "━", Head: "╸", Empty: "─"}
+)
+
+var styles = map[string]Style{
+	"ascii":  StyleASCII,
+	"blocks": StyleBlocks,
+	"dots":   StyleDots,
+	"pipe":   StylePipe,
+}
+
+// LookupStyle returns the built-in style with the given name.
+func LookupStyle(name string) (Style, bool) {
+	s, ok := styles[name]
+	return s, ok
+}
+
+// StyleNames returns the names of the built-in styles in alphabetical order.
+func StyleNames() []string {
+	names := make([]string, 0, len(styles))
+	for name := range styles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// cells returns how many cells of the glyphs of s fit in width. The rest of
+// the width is padded.
+func (s Style) cells(width int) int {
+	return max(width, 0) / max(text.VisibleLength(s.Fill), 1)
+}
+
+// fill returns the glyphs of the filled part of a bar of n cells that is
+// the given fraction complete.
+func (s Style) fill(n int, fraction float64) []string {
+	filled := float64(n) * min(max(fraction, 0), 1)
+	full := min(int(filled), n)
+
+	glyphs := make([]string, full, n)
+	for i := range glyphs {
+		glyphs[i] = s.Fill
+	}
+	if full == n {
+		return glyphs
+	}
+	if len(s.Partials) > 0 {
+		if i := int((filled - float64(full)) * float64(len(s.Partials)+1)); i > 0 {
+			glyphs = append(glyphs, s.Partials[i-1])
+		}
+	} else if full > 0 && s.Head != "" {
+		glyphs[full-1] = s.Head
+	}
+	return glyphs
+}
+
+// styles returns the active theme of the bar. It is called with mtx held.
+func (b *Bar) styles() *theme.Theme {
+	if b.theme == nil {
+		return theme.Current()
+	}
+	return b.theme
+}
+
+// paint colors the filled glyphs of a bar of n cells with the theme. With a
+// gradient, each cell takes the color of its place along the whole bar.
+func paint(t *theme.Theme, glyphs []string, n int) string {
+	if len(glyphs) == 0 {
+		return ""
+	}
+	if len(t.Gradient) < 2 {
+		return t.Progress.Render(strings.Join(glyphs, ""))
+	}
+
+	style := t.Progress.Lipgloss()
+	var sb strings.Builder
+	for i, g := range glyphs {
+		c := gradientAt(t.Gradient, float64(i)/float64(max(n-1, 1)))
+		sb.WriteString(style.Foreground(lipgloss.Color(c)).Render(g))
+	}
+	return sb.String()
+}
+
+// gradientAt returns the color at pos, from 0 to 1, along the colors of a
+// gradient. Colors that are not hex values, such as ANSI color numbers,
+// can't be blended and the nearest one is used.
+func gradientAt(colors []string, pos float64) string {
+	seg := pos * float64(len(colors)-1)
+	i := min(int(seg), len(colors)-2)
+	frac := seg - float64(i)
+
+	from, err1 := colorful.Hex(colors[i])
+	to, err2 := colorful.Hex(colors[i+1])
+	if err1 != nil || err2 != nil {
+		if frac < 0.5 {
+			return colors[i]
+		}
+		return colors[i+1]
+	}
+	return from.BlendLuv(to, frac).Clamped().Hex()
+}
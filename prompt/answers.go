@@ -0,0 +1,102 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AnswerProvider supplies answers for prompts by their ID. A prompt with a
+// provided answer returns it without user interaction, which makes programs
+// using prompts scriptable.
+type AnswerProvider interface {
+	// Answer returns the answer for the prompt with the given ID as text and
+	// reports whether there is one.
+	Answer(id string) (string, bool)
+}
+
+// AnswerFunc is a function that implements AnswerProvider.
+type AnswerFunc func(id string) (string, bool)
+
+// Answer calls f(id).
+func (f AnswerFunc) Answer(id string) (string, bool) {
+	return f(id)
+}
+
+// Answer returns the answer stored under id formatted as text.
+// It allows a map of answers to be used as an AnswerProvider.
+func (a Answers) Answer(id string) (string, bool) {
+	v, ok := a[id]
+	if !ok {
+		return "", false
+	}
+	if v == nil {
+		return "", true
+	}
+	return fmt.Sprint(v), true
+}
+
+var providers []AnswerProvider
+
+// SetAnswerProviders sets the providers that are asked for answers before a
+// prompt is shown. The first provider that has an answer wins.
+func SetAnswerProviders(p ...AnswerProvider) {
+	providers = p
+}
+
+// provided returns the answer for the prompt with the given ID from the first provider that has one.
+func provided(id string) (string, bool) {
+	if id == "" {
+		return "", false
+	}
+	for _, p := range providers {
+		if answer, ok := p.Answer(id); ok {
+			return answer, true
+		}
+	}
+	return "", false
+}
+
+// EnvAnswers returns a provider that reads answers from environment variables.
+// The variable for a prompt is prefix followed by its ID in upper case, with
+// every character that is not a letter or a digit replaced by an underscore.
+// For example the ID "db.port" with the prefix "APP_" is read from APP_DB_PORT.
+func EnvAnswers(prefix string) AnswerProvider {
+	return AnswerFunc(func(id string) (string, bool) {
+		name := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToUpper(r)
+			}
+			return '_'
+		}, id)
+		return os.LookupEnv(prefix + name)
+	})
+}
+
+// LoadAnswers reads answers keyed by prompt ID from a JSON or YAML file.
+// The format is chosen by the extension of the file.
+func LoadAnswers(path string) (Answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make(Answers)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &answers)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &answers)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
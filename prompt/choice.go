@@ -0,0 +1,287 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"atomicgo.dev/keyboard/keys"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Choice is an answer of a ChoicePrompt that is picked by pressing its key.
+type Choice struct {
+	Key  rune   // the shortcut, such as 'y'
+	Name string // the answer, such as "yes"
+	// Remember makes later prompts with the same ID, or label if they have
+	// none, return this answer without asking for the rest of the session.
+	Remember bool
+}
+
+// Choices returns a Choice for every name, using its first letter as the key.
+// If an earlier choice took that letter, the next unused character of the name
+// is used. Choices panics if a name has none left.
+func Choices(names ...string) []Choice {
+	choices := make([]Choice, len(names))
+	used := make(map[rune]bool)
+	for i, name := range names {
+		choices[i] = Choice{Name: name}
+		for _, r := range name {
+			r = unicode.ToLower(r)
+			if !unicode.IsSpace(r) && !used[r] {
+				choices[i].Key = r
+				used[r] = true
+				break
+			}
+		}
+		if choices[i].Key == 0 {
+			panic(fmt.Sprintf("prompt: no unused key for choice %q", name))
+		}
+	}
+	return choices
+}
+
+// remembered holds the answers of choices with Remember set, by prompt.
+var remembered = struct {
+	sync.Mutex
+	answers map[string]string
+}{answers: make(map[string]string)}
+
+// ChoicePrompt asks a question that is answered inline with a single key,
+// such as "Overwrite? [Y]es/[n]o/[a]ll/[q]uit". The answer is echoed in place.
+type ChoicePrompt struct {
+	Base[string]
+	validation[string]
+	choices      []Choice
+	defaultIndex int // -1 if there is no default
+	index        int // the highlighted choice, -1 if there is none
+	result       string
+}
+
+// NewChoicePrompt creates a new ChoicePrompt with the specified label and choices.
+func NewChoicePrompt(label string, choices ...Choice) *ChoicePrompt {
+	p := &ChoicePrompt{
+		Base: Base[string]{
+			label: label,
+		},
+		choices:      choices,
+		defaultIndex: -1,
+	}
+	return p
+}
+
+// SetLabel sets the label for the ChoicePrompt.
+func (p *ChoicePrompt) SetLabel(label string) *ChoicePrompt {
+	p.label = label
+	return p
+}
+
+// SetDefault sets the choice picked when the user presses enter. Its key is
+// shown in upper case and highlighted.
+func (p *ChoicePrompt) SetDefault(name string) {
+	p.defaultIndex = p.find(name)
+}
+
+// WithValidator adds a validator the answer has to pass before it is accepted.
+func (p *ChoicePrompt) WithValidator(v Validator[string]) *ChoicePrompt {
+	p.validators = append(p.validators, v)
+	return p
+}
+
+// WithTransform adds a transform that is applied to the answer before it is validated.
+func (p *ChoicePrompt) WithTransform(t Transform[string]) *ChoicePrompt {
+	p.transforms = append(p.transforms, t)
+	return p
+}
+
+// Forget removes the remembered answer of the prompt, so it asks again.
+func (p *ChoicePrompt) Forget() {
+	remembered.Lock()
+	defer remembered.Unlock()
+	delete(remembered.answers, p.memoryKey())
+}
+
+// memoryKey returns the key of the remembered answer of the prompt.
+func (p *ChoicePrompt) memoryKey() string {
+	if p.id != "" {
+		return p.id
+	}
+	return p.label
+}
+
+// find returns the index of the choice with the given name or key, or -1.
+func (p *ChoicePrompt) find(answer string) int {
+	for i, c := range p.choices {
+		if strings.EqualFold(c.Name, answer) {
+			return i
+		}
+	}
+	if r := []rune(answer); len(r) == 1 {
+		for i, c := range p.choices {
+			if unicode.ToLower(c.Key) == unicode.ToLower(r[0]) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (p *ChoicePrompt) reset() {
+	p.index = p.defaultIndex
+	p.result = ""
+	p.err = nil
+}
+
+func (p *ChoicePrompt) update(key keys.Key) (bool, error) {
+	km := p.keyMap()
+	switch {
+	case km.Cancel.command(key):
+		return false, ErrCanceledPrompt
+	case km.Select.command(key):
+		return p.submit()
+	case km.Left.command(key):
+		p.index = max(p.index-1, 0)
+	case km.Right.command(key):
+		p.index = min(p.index+1, len(p.choices)-1)
+	case key.Code == keys.RuneKey && len(key.Runes) == 1:
+		i := p.find(string(key.Runes))
+		if i < 0 {
+			return false, nil
+		}
+		p.index = i
+		return p.submit()
+	}
+	return false, nil
+}
+
+// submit accepts the highlighted choice. Without a default nothing is
+// highlighted until the user moves to a choice.
+func (p *ChoicePrompt) submit() (bool, error) {
+	if p.index < 0 {
+		return false, nil
+	}
+	p.result, p.err = p.apply(p.choices[p.index].Name)
+	if p.err != nil {
+		return false, nil
+	}
+	if p.choices[p.index].Remember {
+		remembered.Lock()
+		remembered.answers[p.memoryKey()] = p.result
+		remembered.Unlock()
+	}
+	return true, nil
+}
+
+// hint renders the choices with their keys in brackets. The key of the
+// default is in upper case and the highlighted choice stands out.
+func (p *ChoicePrompt) hint() string {
+	t := p.styles()
+	parts := make([]string, len(p.choices))
+	for i, c := range p.choices {
+		key := unicode.ToLower(c.Key)
+		if i == p.defaultIndex {
+			key = unicode.ToUpper(c.Key)
+		}
+
+		text := fmt.Sprintf("[%c] %s", key, c.Name)
+		name := []rune(c.Name)
+		for j, r := range name {
+			if unicode.ToLower(r) == unicode.ToLower(c.Key) {
+				text = string(name[:j]) + "[" + string(key) + "]" + string(name[j+1:])
+				break
+			}
+		}
+
+		if i == p.index {
+			parts[i] = t.Selected.Render(text)
+		} else {
+			parts[i] = t.Muted.Render(text)
+		}
+	}
+	return strings.Join(parts, t.Muted.Render("/"))
+}
+
+func (p *ChoicePrompt) view() (string, int, int) {
+	t := p.styles()
+	line := p.hint()
+	if p.label != "" {
+		line = p.label + " " + line
+	}
+	view := line
+	if p.err != nil {
+		view += "\n" + t.Error.Render(p.err.Error())
+	}
+	return view, 0, lipgloss.Width(line)
+}
+
+func (p *ChoicePrompt) summary() string {
+	if p.label == "" {
+		return p.result
+	}
+	return p.label + " " + p.result
+}
+
+// setAnswer accepts the name or key of a choice in any case. An empty answer selects the default.
+func (p *ChoicePrompt) setAnswer(answer string) error {
+	answer = strings.TrimSpace(answer)
+	i := p.find(answer)
+	if answer == "" {
+		i = p.defaultIndex
+	}
+	if i < 0 {
+		return fmt.Errorf("%w: %q is not a choice", ErrInvalidAnswer, answer)
+	}
+	p.index = i
+	p.result, p.err = p.apply(p.choices[i].Name)
+	return p.err
+}
+
+// useDefault answers the prompt with its default.
+func (p *ChoicePrompt) useDefault() error {
+	return p.setAnswer("")
+}
+
+func (p *ChoicePrompt) answer() any {
+	return p.result
+}
+
+// Run asks the question and returns the name of the picked choice.
+// A remembered answer is returned right away.
+// A provided answer is returned without user interaction, as is a line read
+// from stdin when stdin is not a terminal.
+func (p *ChoicePrompt) Run() (string, error) {
+	return p.RunContext(context.Background())
+}
+
+// RunContext is like Run, but gives up when ctx is done. The prompt is
+// removed, the terminal restored and ctx.Err() returned.
+func (p *ChoicePrompt) RunContext(ctx context.Context) (string, error) {
+	if len(p.choices) == 0 {
+		return "", ErrNoChoices
+	}
+	p.reset()
+	remembered.Lock()
+	answer, ok := remembered.answers[p.memoryKey()]
+	remembered.Unlock()
+	if ok {
+		p.result = answer
+		return answer, nil
+	}
+	if ok, err := scripted(p.id, p, p.interactive()); ok {
+		if err != nil {
+			return "", err
+		}
+		return p.result, nil
+	}
+
+	s := newScreen(p.output())
+	if err := run(ctx, &p.termio, s, p); err != nil {
+		s.clear()
+		return "", err
+	}
+	s.render(p.summary(), -1, 0)
+	s.end()
+	return p.result, nil
+}
@@ -0,0 +1,46 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxSuggestions is the number of suggestions shown beneath the input at once.
+const maxSuggestions = 6
+
+// Completer returns suggestions for the current input of a prompt.
+// It is called in its own goroutine, so a slow completer does not block typing.
+type Completer func(input string) []string
+
+// PathCompleter is a Completer for file system paths.
+// Directories are suggested with a trailing path separator and hidden
+// entries are only suggested once the input starts with a dot.
+func PathCompleter(input string) []string {
+	dir, prefix := filepath.Split(input)
+	readDir := dir
+	if readDir == "" {
+		readDir = "."
+	}
+
+	entries, err := os.ReadDir(readDir)
+	if err != nil {
+		return nil
+	}
+
+	suggestions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".") {
+			continue
+		}
+		if entry.IsDir() {
+			name += string(filepath.Separator)
+		}
+		suggestions = append(suggestions, dir+name)
+	}
+	return suggestions
+}
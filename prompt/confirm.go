@@ -1,19 +1,23 @@
 package prompt
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 
-	"atomicgo.dev/keyboard"
 	"atomicgo.dev/keyboard/keys"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
-	"github.com/muesli/termenv"
-	"github.com/stelmanjones/termtools/internal/theme"
 )
 
 // ConfirmationPrompt is a prompt that asks the user to confirm a certain action.
 type ConfirmationPrompt struct {
 	Base[string]
+	validation[bool]
 	defaultValue bool
+	value        bool
+	result       bool
 }
 
 // NewConfirmationPrompt creates a new ConfirmationPrompt with the specified label.
@@ -32,83 +36,128 @@ func (p *ConfirmationPrompt) SetLabel(label string) *ConfirmationPrompt {
 	return p
 }
 
+// SetDefault sets the answer used when the user presses enter.
 func (p *ConfirmationPrompt) SetDefault(v bool) {
 	p.defaultValue = v
 }
 
-func (p *ConfirmationPrompt) render(out *termenv.Output) {
-	if p.label != "" {
+// WithValidator adds a validator the answer has to pass before it is accepted.
+func (p *ConfirmationPrompt) WithValidator(v Validator[bool]) *ConfirmationPrompt {
+	p.validators = append(p.validators, v)
+	return p
+}
 
-		_, err := out.WriteString(p.label + theme.Dimmed.Render(" (y/n)"))
-		if err != nil {
-			fmt.Println(err)
-		}
+// WithTransform adds a transform that is applied to the answer before it is validated.
+func (p *ConfirmationPrompt) WithTransform(t Transform[bool]) *ConfirmationPrompt {
+	p.transforms = append(p.transforms, t)
+	return p
+}
+
+func (p *ConfirmationPrompt) reset() {
+	p.value = p.defaultValue
+	p.err = nil
+}
+
+func (p *ConfirmationPrompt) update(key keys.Key) (bool, error) {
+	km := p.keyMap()
+	switch {
+	case km.Cancel.Matches(key):
+		return false, ErrCanceledPrompt
+	case km.Select.Matches(key):
+	case km.Yes.Matches(key):
+		p.value = true
+	case km.No.Matches(key):
+		p.value = false
+	default:
+		return false, nil
+	}
+	return p.submit()
+}
+
+// submit accepts the current answer.
+func (p *ConfirmationPrompt) submit() (bool, error) {
+	p.result, p.err = p.apply(p.value)
+	return p.err == nil, nil
+}
+
+func (p *ConfirmationPrompt) view() (string, int, int) {
+	if p.label == "" {
+		return "", -1, 0
+	}
+	t := p.styles()
+	hint := " (y/N)"
+	if p.defaultValue {
+		hint = " (Y/n)"
+	}
+	line := p.label + t.Muted.Render(hint)
+	view := line
+	if p.err != nil {
+		view += "\n" + t.Error.Render(p.err.Error())
+	}
+	return view, 0, lipgloss.Width(line)
+}
+
+func (p *ConfirmationPrompt) summary() string {
+	if p.result {
+		return p.label + " yes"
+	}
+	return p.label + " no"
+}
+
+// setAnswer accepts y, yes, true, 1, n, no, false and 0 in any case.
+// An empty answer selects the default.
+func (p *ConfirmationPrompt) setAnswer(answer string) error {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "":
+		p.value = p.defaultValue
+	case "y", "yes", "true", "1":
+		p.value = true
+	case "n", "no", "false", "0":
+		p.value = false
+	default:
+		return fmt.Errorf("%w: %q is not yes or no", ErrInvalidAnswer, answer)
 	}
+	p.result, p.err = p.apply(p.value)
+	return p.err
+}
+
+// useDefault answers the prompt with its default.
+func (p *ConfirmationPrompt) useDefault() error {
+	return p.setAnswer("")
+}
+
+func (p *ConfirmationPrompt) answer() any {
+	return p.result
 }
 
 // Run executes the confirmation prompt and returns the user's response.
 // It renders the prompt, listens for user input, and handles the response accordingly.
+// An answer is refused until it passes all validators.
+// A provided answer is returned without user interaction, as is a line read
+// from stdin when stdin is not a terminal.
 func (p *ConfirmationPrompt) Run() (bool, error) {
-	out := termenv.DefaultOutput()
-	p.render(out)
-	var result bool
-	ch := make(chan keys.Key)
-	defer close(ch)
-	go keyboard.Listen(func(key keys.Key) (stop bool, err error) {
-		switch key.Code {
-		case keys.RuneKey:
-			switch key.String() {
-			case "y", "Y", "n", "N":
-				ch <- key
-				return true, nil
-
-			default:
-				ch <- key
-				return false, nil
-
-			}
-		case keys.Enter:
-			ch <- key
-			return true, nil
-		default:
-			{
-				ch <- key
-				return false, nil
-			}
-		}
-	})
-outer:
-	for key := range ch {
-		switch key.Code {
-		case keys.CtrlC, keys.CtrlD:
-			out.ClearLine()
-			log.Error("User cancelled")
-			return false, ErrCanceledPrompt
-		case keys.Enter:
-			result = p.defaultValue
-			break outer
-		case keys.RuneKey:
-			switch key.String() {
-			case "y", "Y":
-				result = true
-				break outer
-
-			case "n", "N":
-				result = false
-				break outer
-
-			default:
-				continue
-
-			}
-		default:
-			continue
+	return p.RunContext(context.Background())
+}
 
+// RunContext is like Run, but gives up when ctx is done. The prompt is
+// removed, the terminal restored and ctx.Err() returned.
+func (p *ConfirmationPrompt) RunContext(ctx context.Context) (bool, error) {
+	p.reset()
+	if ok, err := scripted(p.id, p, p.interactive()); ok {
+		if err != nil {
+			return false, err
 		}
+		return p.result, nil
 	}
 
-	out.ClearLine()
-	out.MoveCursor(0, 0)
-
-	return result, nil
+	s := newScreen(p.output())
+	err := run(ctx, &p.termio, s, p)
+	s.clear()
+	if err != nil {
+		if errors.Is(err, ErrCanceledPrompt) {
+			log.Error("User cancelled")
+		}
+		return false, err
+	}
+	return p.result, nil
 }
@@ -0,0 +1,300 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"atomicgo.dev/keyboard/keys"
+)
+
+// DatePrompt is a prompt for a date, picked from a calendar. The arrow keys
+// move by day and week, page up and page down by month.
+type DatePrompt struct {
+	Base[string]
+	validation[time.Time]
+	defaultValue time.Time
+	format       string
+	min, max     time.Time // zero means unbounded
+	weekStart    time.Weekday
+	hideControls bool
+	cursor       time.Time
+	result       time.Time
+}
+
+// NewDatePrompt creates a new DatePrompt with the provided label.
+// It starts at today and formats dates as 2006-01-02.
+func NewDatePrompt(label string) *DatePrompt {
+	p := &DatePrompt{
+		Base: Base[string]{
+			label: label,
+		},
+		format:    time.DateOnly,
+		weekStart: time.Monday,
+	}
+	return p
+}
+
+// SetLabel sets the label of the DatePrompt.
+func (p *DatePrompt) SetLabel(label string) {
+	p.label = label
+}
+
+// SetDefault sets the date the calendar starts at instead of today.
+func (p *DatePrompt) SetDefault(v time.Time) {
+	p.defaultValue = v
+}
+
+// WithFormat sets the layout, as used by time.Format, of the date returned by
+// RunString, shown in the summary and expected from scripted answers.
+func (p *DatePrompt) WithFormat(layout string) *DatePrompt {
+	p.format = layout
+	return p
+}
+
+// WithRange only allows dates between min and max, inclusive.
+// A zero time leaves that end of the range open.
+func (p *DatePrompt) WithRange(min, max time.Time) *DatePrompt {
+	p.min, p.max = dateOf(min), dateOf(max)
+	return p
+}
+
+// WithWeekStart sets the first day of the week in the calendar, Monday by default.
+func (p *DatePrompt) WithWeekStart(d time.Weekday) *DatePrompt {
+	p.weekStart = d
+	return p
+}
+
+// WithValidator adds a validator the date has to pass before it is accepted.
+func (p *DatePrompt) WithValidator(v Validator[time.Time]) *DatePrompt {
+	p.validators = append(p.validators, v)
+	return p
+}
+
+// WithTransform adds a transform that is applied to the date before it is validated.
+func (p *DatePrompt) WithTransform(t Transform[time.Time]) *DatePrompt {
+	p.transforms = append(p.transforms, t)
+	return p
+}
+
+// dateOf returns midnight of the day of t in the local time zone.
+func dateOf(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// start returns the date the calendar starts at.
+func (p *DatePrompt) start() time.Time {
+	if p.defaultValue.IsZero() {
+		return dateOf(time.Now())
+	}
+	return dateOf(p.defaultValue)
+}
+
+// clamp keeps t within the range.
+func (p *DatePrompt) clamp(t time.Time) time.Time {
+	if !p.min.IsZero() && t.Before(p.min) {
+		return p.min
+	}
+	if !p.max.IsZero() && t.After(p.max) {
+		return p.max
+	}
+	return t
+}
+
+// inRange reports whether t lies within the range.
+func (p *DatePrompt) inRange(t time.Time) bool {
+	return p.clamp(t).Equal(t)
+}
+
+func (p *DatePrompt) reset() {
+	p.cursor = p.clamp(p.start())
+	p.result = time.Time{}
+	p.err = nil
+}
+
+// moveMonths moves the cursor by n months. The day is kept if the month has
+// it, otherwise the last day of the month is used.
+func (p *DatePrompt) moveMonths(n int) {
+	y, m, d := p.cursor.Date()
+	first := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, time.Local)
+	last := first.AddDate(0, 1, -1).Day()
+	p.cursor = p.clamp(time.Date(first.Year(), first.Month(), min(d, last), 0, 0, 0, 0, time.Local))
+}
+
+func (p *DatePrompt) update(key keys.Key) (bool, error) {
+	km := p.keyMap()
+	switch {
+	case km.Cancel.Matches(key):
+		return false, ErrCanceledPrompt
+	case km.Select.Matches(key):
+		return p.submit()
+	case km.Left.Matches(key):
+		p.cursor = p.clamp(p.cursor.AddDate(0, 0, -1))
+	case km.Right.Matches(key):
+		p.cursor = p.clamp(p.cursor.AddDate(0, 0, 1))
+	case km.Up.Matches(key):
+		p.cursor = p.clamp(p.cursor.AddDate(0, 0, -7))
+	case km.Down.Matches(key):
+		p.cursor = p.clamp(p.cursor.AddDate(0, 0, 7))
+	case km.PrevPage.Matches(key):
+		p.moveMonths(-1)
+	case km.NextPage.Matches(key):
+		p.moveMonths(1)
+	default:
+		return false, nil
+	}
+	p.err = nil
+	return false, nil
+}
+
+// submit accepts the date under the cursor.
+func (p *DatePrompt) submit() (bool, error) {
+	p.result, p.err = p.apply(p.cursor)
+	return p.err == nil, nil
+}
+
+// view renders the label, the calendar of the month of the cursor, the last
+// validation error and the controls.
+func (p *DatePrompt) view() (string, int, int) {
+	t := p.styles()
+	cursor := t.Selected
+	cursor.Reverse = true
+	today := dateOf(time.Now())
+
+	var sb strings.Builder
+	if p.label != "" {
+		sb.WriteString(p.label + "\n")
+	}
+
+	const width = 7*3 - 1
+	title := p.cursor.Format("January 2006")
+	sb.WriteString(strings.Repeat(" ", (width-len(title))/2) + t.Title.Render(title) + "\n")
+
+	names := make([]string, 7)
+	for i := range names {
+		names[i] = time.Weekday((int(p.weekStart) + i) % 7).String()[:2]
+	}
+	sb.WriteString(t.Muted.Render(strings.Join(names, " ")) + "\n")
+
+	first := time.Date(p.cursor.Year(), p.cursor.Month(), 1, 0, 0, 0, 0, time.Local)
+	offset := (int(first.Weekday()) - int(p.weekStart) + 7) % 7
+	sb.WriteString(strings.Repeat("   ", offset))
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		cell := fmt.Sprintf("%2d", day.Day())
+		switch {
+		case day.Equal(p.cursor):
+			cell = cursor.Render(cell)
+		case !p.inRange(day):
+			cell = t.Muted.Render(cell)
+		case day.Equal(today):
+			cell = t.Accent.Render(cell)
+		}
+		sb.WriteString(cell)
+
+		if (offset+day.Day())%7 == 0 {
+			sb.WriteString("\n")
+		} else if day.AddDate(0, 0, 1).Month() == first.Month() {
+			sb.WriteString(" ")
+		}
+	}
+
+	view := strings.TrimSuffix(sb.String(), "\n")
+	if p.err != nil {
+		view += "\n" + t.Error.Render(p.err.Error())
+	}
+	if !p.hideControls {
+		km := p.keyMap()
+		view += "\n" + t.Muted.Render(help(
+			NewBinding("day", append(km.Left.Keys, km.Right.Keys...)...),
+			NewBinding("week", append(km.Up.Keys, km.Down.Keys...)...),
+			NewBinding("month", append(km.PrevPage.Keys, km.NextPage.Keys...)...),
+			km.Select,
+		))
+	}
+	return view, -1, 0
+}
+
+// embed hides the controls of the prompt when it is part of a Form.
+func (p *DatePrompt) embed() {
+	p.hideControls = true
+}
+
+func (p *DatePrompt) summary() string {
+	value := p.result.Format(p.format)
+	if p.label == "" {
+		return value
+	}
+	return p.label + " " + value
+}
+
+// setAnswer accepts a date in the format of the prompt. An empty answer selects the default.
+func (p *DatePrompt) setAnswer(answer string) error {
+	date := p.clamp(p.start())
+	if answer = strings.TrimSpace(answer); answer != "" {
+		t, err := time.ParseInLocation(p.format, answer, time.Local)
+		if err != nil {
+			return fmt.Errorf("%w: %q is not a date like %s", ErrInvalidAnswer, answer, p.format)
+		}
+		if date = dateOf(t); !p.inRange(date) {
+			return fmt.Errorf("%w: %s is out of range", ErrInvalidAnswer, answer)
+		}
+	}
+	p.cursor = date
+	p.result, p.err = p.apply(date)
+	return p.err
+}
+
+// useDefault answers the prompt with its default.
+func (p *DatePrompt) useDefault() error {
+	return p.setAnswer("")
+}
+
+func (p *DatePrompt) answer() any {
+	return p.result
+}
+
+// Run starts the DatePrompt and returns the picked date at midnight in the local time zone.
+// A provided answer is returned without user interaction, as is a line read
+// from stdin when stdin is not a terminal.
+func (p *DatePrompt) Run() (time.Time, error) {
+	return p.RunContext(context.Background())
+}
+
+// RunContext is like Run, but gives up when ctx is done. The prompt is
+// removed, the terminal restored and ctx.Err() returned.
+func (p *DatePrompt) RunContext(ctx context.Context) (time.Time, error) {
+	p.reset()
+	if ok, err := scripted(p.id, p, p.interactive()); ok {
+		if err != nil {
+			return time.Time{}, err
+		}
+		return p.result, nil
+	}
+
+	out := p.output()
+	out.HideCursor()
+	defer out.ShowCursor()
+	s := newScreen(out)
+	err := run(ctx, &p.termio, s, p)
+	s.clear()
+	if err != nil {
+		return time.Time{}, err
+	}
+	s.render(p.summary(), -1, 0)
+	s.end()
+	return p.result, nil
+}
+
+// RunString runs the prompt and returns the picked date in the format of the prompt.
+func (p *DatePrompt) RunString() (string, error) {
+	t, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+	return t.Format(p.format), nil
+}
@@ -0,0 +1,566 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"atomicgo.dev/keyboard/keys"
+	"github.com/mattn/go-runewidth"
+)
+
+// errExternalEditor stops the input loop of an EditorPrompt so the text can be edited in $EDITOR.
+var errExternalEditor = errors.New("open external editor")
+
+// EditorPrompt is a prompt for multi-line text, such as commit messages.
+// Enter inserts a line break, the Submit binding (ctrl+d by default) submits
+// the text and the External binding (ctrl+e by default) opens it in $EDITOR.
+// Long lines are soft wrapped.
+type EditorPrompt struct {
+	Base[string]
+	validation[string]
+	defaultValue string
+	lineNumbers  bool
+	maxLines     int // 0 means unlimited
+	width        int // 0 means the width of the terminal
+	embedded     bool
+	lines        [][]rune
+	row, col     int // position of the cursor in lines
+	result       string
+}
+
+// NewEditorPrompt creates a new EditorPrompt with the provided label.
+func NewEditorPrompt(label string) *EditorPrompt {
+	p := &EditorPrompt{
+		Base: Base[string]{
+			label: label,
+		},
+	}
+	return p
+}
+
+// SetLabel sets the label of the EditorPrompt.
+func (p *EditorPrompt) SetLabel(label string) {
+	p.label = label
+}
+
+// SetDefault sets the text the editor starts with.
+func (p *EditorPrompt) SetDefault(v string) {
+	p.defaultValue = v
+}
+
+// WithLineNumbers shows line numbers in front of every line.
+func (p *EditorPrompt) WithLineNumbers() *EditorPrompt {
+	p.lineNumbers = true
+	return p
+}
+
+// WithMaxLines limits the text to n lines.
+func (p *EditorPrompt) WithMaxLines(n int) *EditorPrompt {
+	p.maxLines = n
+	return p
+}
+
+// WithWidth wraps lines at n columns instead of the width of the terminal.
+func (p *EditorPrompt) WithWidth(n int) *EditorPrompt {
+	p.width = n
+	return p
+}
+
+// WithValidator adds a validator the text has to pass before it is accepted.
+func (p *EditorPrompt) WithValidator(v Validator[string]) *EditorPrompt {
+	p.validators = append(p.validators, v)
+	return p
+}
+
+// WithTransform adds a transform that is applied to the text before it is validated.
+func (p *EditorPrompt) WithTransform(t Transform[string]) *EditorPrompt {
+	p.transforms = append(p.transforms, t)
+	return p
+}
+
+func (p *EditorPrompt) reset() {
+	p.setText(p.defaultValue)
+	p.result = ""
+	p.err = nil
+}
+
+// setText replaces the text and moves the cursor to its end.
+// Lines beyond the maximum number of lines are dropped.
+func (p *EditorPrompt) setText(s string) {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	p.lines = nil
+	for _, line := range strings.Split(s, "\n") {
+		p.lines = append(p.lines, []rune(line))
+	}
+	if p.maxLines > 0 && len(p.lines) > p.maxLines {
+		p.lines = p.lines[:p.maxLines]
+	}
+	p.row = len(p.lines) - 1
+	p.col = len(p.lines[p.row])
+}
+
+// text returns the lines joined by line breaks.
+func (p *EditorPrompt) text() string {
+	lines := make([]string, len(p.lines))
+	for i, line := range p.lines {
+		lines[i] = string(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// embed disables the external editor when the prompt is part of a Form.
+func (p *EditorPrompt) embed() {
+	p.embedded = true
+}
+
+func (p *EditorPrompt) update(key keys.Key) (bool, error) {
+	km := p.keyMap()
+	switch {
+	case km.Submit.command(key):
+		return p.submit()
+
+	case km.Cancel.command(key):
+		return false, ErrCanceledPrompt
+
+	case km.External.command(key):
+		if p.embedded {
+			return false, nil
+		}
+		return false, errExternalEditor
+
+	case key.Code == keys.Enter:
+		p.newline()
+
+	case key.Code == keys.Backspace:
+		p.backspace()
+
+	case key.Code == keys.Delete:
+		p.delete()
+
+	case key.Code == keys.Left:
+		p.left()
+
+	case key.Code == keys.Right:
+		p.right()
+
+	case key.Code == keys.Up:
+		p.up()
+
+	case key.Code == keys.Down:
+		p.down()
+
+	case key.Code == keys.Home:
+		p.col = 0
+
+	case key.Code == keys.End:
+		p.col = len(p.lines[p.row])
+
+	case key.Code == keys.Tab:
+		p.insert([]rune{'\t'})
+
+	default:
+		p.insert(key.Runes)
+	}
+	p.err = nil
+	return false, nil
+}
+
+// submit accepts the text.
+func (p *EditorPrompt) submit() (bool, error) {
+	p.result, p.err = p.apply(p.text())
+	return p.err == nil, nil
+}
+
+// insert inserts runes at the cursor. Line breaks in pasted text start new lines.
+func (p *EditorPrompt) insert(runes []rune) {
+	for _, r := range runes {
+		switch r {
+		case '\r':
+		case '\n':
+			p.newline()
+		default:
+			line := p.lines[p.row]
+			line = append(line[:p.col], append([]rune{r}, line[p.col:]...)...)
+			p.lines[p.row] = line
+			p.col++
+		}
+	}
+}
+
+// newline splits the current line at the cursor, unless the maximum number of lines is reached.
+func (p *EditorPrompt) newline() {
+	if p.maxLines > 0 && len(p.lines) >= p.maxLines {
+		return
+	}
+	line := p.lines[p.row]
+	rest := append([]rune(nil), line[p.col:]...)
+	p.lines[p.row] = line[:p.col]
+	p.lines = append(p.lines[:p.row+1], append([][]rune{rest}, p.lines[p.row+1:]...)...)
+	p.row++
+	p.col = 0
+}
+
+// backspace removes the character before the cursor or joins the line with the previous one.
+func (p *EditorPrompt) backspace() {
+	if p.col > 0 {
+		line := p.lines[p.row]
+		p.lines[p.row] = append(line[:p.col-1], line[p.col:]...)
+		p.col--
+		return
+	}
+	if p.row == 0 {
+		return
+	}
+	prev := p.lines[p.row-1]
+	p.col = len(prev)
+	p.lines[p.row-1] = append(prev, p.lines[p.row]...)
+	p.lines = append(p.lines[:p.row], p.lines[p.row+1:]...)
+	p.row--
+}
+
+// delete removes the character under the cursor or joins the next line with the current one.
+func (p *EditorPrompt) delete() {
+	line := p.lines[p.row]
+	if p.col < len(line) {
+		p.lines[p.row] = append(line[:p.col], line[p.col+1:]...)
+		return
+	}
+	if p.row == len(p.lines)-1 {
+		return
+	}
+	p.lines[p.row] = append(line, p.lines[p.row+1]...)
+	p.lines = append(p.lines[:p.row+1], p.lines[p.row+2:]...)
+}
+
+func (p *EditorPrompt) left() {
+	switch {
+	case p.col > 0:
+		p.col--
+	case p.row > 0:
+		p.row--
+		p.col = len(p.lines[p.row])
+	}
+}
+
+func (p *EditorPrompt) right() {
+	switch {
+	case p.col < len(p.lines[p.row]):
+		p.col++
+	case p.row < len(p.lines)-1:
+		p.row++
+		p.col = 0
+	}
+}
+
+// up moves the cursor to the wrapped row above, keeping its column if possible.
+func (p *EditorPrompt) up() {
+	w := p.wrapWidth()
+	line := p.lines[p.row]
+	starts := wrap(line, w)
+	seg, x := position(line, starts, p.col)
+	switch {
+	case seg > 0:
+		p.col = column(line, starts, seg-1, x)
+	case p.row > 0:
+		p.row--
+		line = p.lines[p.row]
+		starts = wrap(line, w)
+		p.col = column(line, starts, len(starts)-1, x)
+	}
+}
+
+// down moves the cursor to the wrapped row below, keeping its column if possible.
+func (p *EditorPrompt) down() {
+	w := p.wrapWidth()
+	line := p.lines[p.row]
+	starts := wrap(line, w)
+	seg, x := position(line, starts, p.col)
+	switch {
+	case seg < len(starts)-1:
+		p.col = column(line, starts, seg+1, x)
+	case p.row < len(p.lines)-1:
+		p.row++
+		line = p.lines[p.row]
+		p.col = column(line, wrap(line, w), 0, x)
+	}
+}
+
+// tabWidth is the distance between the tab stops of the editor.
+const tabWidth = 4
+
+// cellWidth returns the number of cells r takes when it starts at cell x of
+// a row. A tab reaches to the next tab stop.
+func cellWidth(r rune, x int) int {
+	if r == '\t' {
+		return tabWidth - x%tabWidth
+	}
+	return runewidth.RuneWidth(r)
+}
+
+// cells returns the number of cells a row takes.
+func cells(row []rune) int {
+	x := 0
+	for _, r := range row {
+		x += cellWidth(r, x)
+	}
+	return x
+}
+
+// wrap returns the index of the first rune of every row line is wrapped
+// into at width cells.
+func wrap(line []rune, width int) []int {
+	starts := []int{0}
+	x := 0
+	for i, r := range line {
+		if w := cellWidth(r, x); x > 0 && x+w > width {
+			starts = append(starts, i)
+			x = 0
+		}
+		x += cellWidth(r, x)
+	}
+	return starts
+}
+
+// position returns the row of a line wrapped at starts that holds the cursor
+// at index col, and the cell of the cursor in that row. A cursor at the end
+// of a full row stays on that row.
+func position(line []rune, starts []int, col int) (int, int) {
+	seg := 0
+	for seg+1 < len(starts) && starts[seg+1] <= col {
+		seg++
+	}
+	return seg, cells(line[starts[seg]:col])
+}
+
+// column returns the index of the cursor at cell x of row seg of a line
+// wrapped at starts, or the end of the row if it is shorter.
+func column(line []rune, starts []int, seg, x int) int {
+	end := len(line)
+	if seg+1 < len(starts) {
+		end = starts[seg+1] - 1 // the last rune of the row, so the cursor stays on it
+	}
+	c := 0
+	for i := starts[seg]; i < end; i++ {
+		if c += cellWidth(line[i], c); c > x {
+			return i
+		}
+	}
+	return end
+}
+
+// expand renders a row with its tabs replaced by spaces.
+func expand(row []rune) string {
+	var sb strings.Builder
+	x := 0
+	for _, r := range row {
+		w := cellWidth(r, x)
+		if r == '\t' {
+			sb.WriteString(strings.Repeat(" ", w))
+		} else {
+			sb.WriteRune(r)
+		}
+		x += w
+	}
+	return sb.String()
+}
+
+// gutterWidth returns the width of the line numbers in front of every row.
+func (p *EditorPrompt) gutterWidth() int {
+	if !p.lineNumbers {
+		return 2
+	}
+	return len(fmt.Sprint(len(p.lines))) + 3
+}
+
+// wrapWidth returns the number of cells shown per row.
+func (p *EditorPrompt) wrapWidth() int {
+	width := p.width
+	if width <= 0 {
+		width = p.columns() - (p.gutterWidth() + 1)
+	}
+	return max(width, 1)
+}
+
+// view renders the label, the wrapped lines with the cursor, the last validation error and the controls.
+func (p *EditorPrompt) view() (string, int, int) {
+	t := p.styles()
+	w := p.wrapWidth()
+	digits := len(fmt.Sprint(len(p.lines)))
+
+	var sb strings.Builder
+	row, col := 0, 0
+	rows := 0
+	if p.label != "" {
+		sb.WriteString(p.label + "\n")
+		rows++
+	}
+	for i, line := range p.lines {
+		starts := wrap(line, w)
+		if i == p.row {
+			seg, x := position(line, starts, p.col)
+			row, col = rows+seg, p.gutterWidth()+x
+		}
+		for s, start := range starts {
+			gutter := "│ "
+			switch {
+			case p.lineNumbers && s == 0:
+				gutter = fmt.Sprintf("%*d │ ", digits, i+1)
+			case p.lineNumbers:
+				gutter = strings.Repeat(" ", digits) + " │ "
+			}
+			end := len(line)
+			if s+1 < len(starts) {
+				end = starts[s+1]
+			}
+			sb.WriteString(t.Muted.Render(gutter) + expand(line[start:end]) + "\n")
+			rows++
+		}
+	}
+	if p.err != nil {
+		sb.WriteString(t.Error.Render(p.err.Error()) + "\n")
+	}
+	if !p.embedded {
+		km := p.keyMap()
+		sb.WriteString(t.Muted.Render(help(km.Submit, km.External, km.Cancel.without(km.Submit))))
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), row, col
+}
+
+// summary renders the label and the first line of the text.
+func (p *EditorPrompt) summary() string {
+	first, _, more := strings.Cut(p.result, "\n")
+	if more {
+		first += " …"
+	}
+	return p.line(first)
+}
+
+// line returns the label followed by value.
+func (p *EditorPrompt) line(value string) string {
+	if p.label == "" {
+		return value
+	}
+	return p.label + " " + value
+}
+
+func (p *EditorPrompt) setAnswer(answer string) error {
+	p.setText(answer)
+	p.result, p.err = p.apply(p.text())
+	return p.err
+}
+
+// useDefault answers the prompt with its default text.
+func (p *EditorPrompt) useDefault() error {
+	return p.setAnswer(p.defaultValue)
+}
+
+func (p *EditorPrompt) answer() any {
+	return p.result
+}
+
+// Run starts the EditorPrompt and returns the text.
+// A provided answer is returned without user interaction. If stdin is not a
+// terminal, all of stdin is read as the text.
+func (p *EditorPrompt) Run() (string, error) {
+	return p.RunContext(context.Background())
+}
+
+// RunContext is like Run, but gives up when ctx is done. The prompt is
+// removed, the terminal restored and ctx.Err() returned.
+func (p *EditorPrompt) RunContext(ctx context.Context) (string, error) {
+	p.reset()
+	if answer, ok := provided(p.id); ok {
+		if err := p.setAnswer(answer); err != nil {
+			return "", err
+		}
+		return p.result, nil
+	}
+	if !p.interactive() {
+		if fallback == FailFast {
+			return "", ErrNotTerminal
+		}
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", err
+		}
+		if err := p.setAnswer(strings.TrimSuffix(string(data), "\n")); err != nil {
+			return "", err
+		}
+		return p.result, nil
+	}
+
+	s := newScreen(p.output())
+	for {
+		err := run(ctx, &p.termio, s, p)
+		s.clear()
+		if !errors.Is(err, errExternalEditor) {
+			if err != nil {
+				return "", err
+			}
+			break
+		}
+		if err := p.openExternal(); err != nil {
+			p.err = err
+		}
+	}
+
+	s.render(p.summary(), -1, 0)
+	s.end()
+	return p.result, nil
+}
+
+// openExternal writes the text to a temporary file, opens it in the editor
+// named by $VISUAL or $EDITOR and reads the edited text back.
+func (p *EditorPrompt) openExternal() error {
+	f, err := os.CreateTemp("", "prompt-*.txt")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(p.text())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	// the editor draws on the terminal of the prompt, which it needs as a file
+	var stdout io.Writer = p.output()
+	if tty := p.output().TTY(); tty != nil {
+		stdout = tty
+	}
+	args := append(editorCommand(), f.Name())
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		return err
+	}
+	p.setText(strings.TrimSuffix(string(data), "\n"))
+	return nil
+}
+
+// editorCommand returns the command of the editor of the user.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{"notepad"}
+	}
+	return []string{"vi"}
+}
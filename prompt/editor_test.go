@@ -0,0 +1,58 @@
+package prompt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// rows returns the rows of the text in the view of p, without the gutter.
+func rows(p *EditorPrompt) []string {
+	view, _, _ := p.view()
+	var rows []string
+	for _, line := range strings.Split(view, "\n") {
+		if _, row, ok := strings.Cut(line, "│ "); ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func TestEditorWrapsWideRunes(t *testing.T) {
+	p := NewEditorPrompt("").WithWidth(5)
+	p.reset()
+	p.insert([]rune("日本語です"))
+
+	got := rows(p)
+	want := []string{"日本", "語で", "す"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("got rows %q, want %q", got, want)
+	}
+	for _, row := range got {
+		if w := runewidth.StringWidth(row); w > 5 {
+			t.Errorf("row %q is %d cells wide", row, w)
+		}
+	}
+	if _, row, col := p.view(); row != 2 || col != p.gutterWidth()+2 {
+		t.Errorf("cursor at %d,%d, want 2,%d", row, col, p.gutterWidth()+2)
+	}
+
+	p.up()
+	if _, row, col := p.view(); row != 1 || col != p.gutterWidth()+2 || p.col != 3 {
+		t.Errorf("cursor at %d,%d (rune %d) after up, want 1,%d (rune 3)", row, col, p.col, p.gutterWidth()+2)
+	}
+}
+
+func TestEditorExpandsTabs(t *testing.T) {
+	p := NewEditorPrompt("").WithWidth(20)
+	p.reset()
+	p.insert([]rune("a\tb"))
+
+	if got := rows(p); len(got) != 1 || got[0] != "a   b" {
+		t.Fatalf("got rows %q, want %q", got, "a   b")
+	}
+	if _, _, col := p.view(); col != p.gutterWidth()+5 {
+		t.Errorf("cursor in column %d, want %d", col, p.gutterWidth()+5)
+	}
+}
@@ -8,4 +8,19 @@ var (
 	// ErrCanceledPrompt is returned when the user cancels the prompt.
 	ErrCanceledPrompt = errors.New("user canceled")
 	ErrNoChoices      = errors.New("selection prompt cannot be empty")
+	// ErrNotTerminal is returned when a prompt requires a terminal but stdin is not one.
+	ErrNotTerminal = errors.New("stdin is not a terminal")
+	// ErrPasswordMismatch is returned when the confirmation of a secret does not match.
+	ErrPasswordMismatch = errors.New("entries do not match")
+	// ErrInvalidBindTarget is returned when answers are bound to something other than a pointer to a struct.
+	ErrInvalidBindTarget = errors.New("bind target must be a pointer to a struct")
+	// ErrBindType is returned when an answer cannot be assigned to the struct field it is bound to.
+	ErrBindType = errors.New("cannot bind answer")
+	// ErrInvalidAnswer is returned when a scripted answer cannot be used for a prompt.
+	ErrInvalidAnswer = errors.New("invalid answer")
+	// ErrMultiSelect is returned by FilePrompt.RunContext when the prompt picks
+	// several paths, which only RunMulti and RunMultiContext return.
+	ErrMultiSelect = errors.New("file prompt picks several paths")
+	// ErrUnknownFormat is returned when an answers file is neither JSON nor YAML.
+	ErrUnknownFormat = errors.New("unknown answers file format")
 )
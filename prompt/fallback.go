@@ -0,0 +1,70 @@
+package prompt
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Fallback decides what prompts do when stdin is not a terminal and no
+// answer is provided for them.
+type Fallback int
+
+const (
+	// ReadLine reads the answer as a single line from stdin.
+	ReadLine Fallback = iota
+	// FailFast returns ErrNotTerminal.
+	FailFast
+)
+
+var (
+	fallback = ReadLine
+	stdin    = bufio.NewReader(os.Stdin)
+)
+
+// SetFallback sets what prompts do when stdin is not a terminal.
+func SetFallback(f Fallback) {
+	fallback = f
+}
+
+// isTerminal reports whether stdin is a terminal.
+func isTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// answerer is implemented by prompts that accept their answer as text.
+type answerer interface {
+	setAnswer(answer string) error
+}
+
+// scripted answers a prompt without user interaction, using the answer
+// provided for id or, if the prompt is not interactive, a line read from stdin.
+// It reports whether the prompt was answered this way.
+func scripted(id string, a answerer, interactive bool) (bool, error) {
+	if answer, ok := provided(id); ok {
+		return true, a.setAnswer(answer)
+	}
+	if interactive {
+		return false, nil
+	}
+	if fallback == FailFast {
+		return true, ErrNotTerminal
+	}
+
+	line, err := readLine(stdin)
+	if err != nil {
+		return true, err
+	}
+	return true, a.setAnswer(line)
+}
+
+// readLine reads a single line from r without the trailing line break.
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,523 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"atomicgo.dev/keyboard/keys"
+)
+
+// defaultFileHeight is the number of entries a FilePrompt shows at once.
+const defaultFileHeight = 10
+
+// FilePrompt is a prompt that browses the file system for a file or directory.
+// Directories are read in the background, so large ones do not block the prompt.
+type FilePrompt struct {
+	Base[string]
+	validation[string]
+	list         *SelectionPrompt[string] // lists the absolute paths of the entries of dir
+	start        string
+	patterns     []string
+	showHidden   bool
+	dirsOnly     bool
+	multi        bool
+	hideControls bool
+	marked       []string
+	result       []string
+
+	mu         sync.Mutex // guards the fields below, which are written by directory reads
+	dir        string
+	dirs       map[string]bool // the entries of dir that are directories
+	loading    bool
+	readErr    error
+	generation int
+	redraw     func()
+}
+
+// NewFilePrompt creates a new FilePrompt with the provided label.
+// It starts in the working directory.
+func NewFilePrompt(label string) *FilePrompt {
+	p := &FilePrompt{
+		Base: Base[string]{
+			label: label,
+		},
+		list:  NewSelectionPrompt[string]().WithHeight(defaultFileHeight).WithFuzzyFilter(),
+		start: ".",
+	}
+	p.list.display = p.display
+	p.list.mark = p.markOf
+	return p
+}
+
+// SetLabel sets the label of the FilePrompt.
+func (p *FilePrompt) SetLabel(label string) {
+	p.label = label
+}
+
+// WithDir sets the directory the prompt starts in.
+func (p *FilePrompt) WithDir(dir string) *FilePrompt {
+	p.start = dir
+	return p
+}
+
+// WithPattern only lists files whose name matches one of the patterns, as
+// used by filepath.Match. Directories are always listed.
+func (p *FilePrompt) WithPattern(patterns ...string) *FilePrompt {
+	p.patterns = append(p.patterns, patterns...)
+	return p
+}
+
+// WithExtensions only lists files with one of the extensions, such as ".go".
+func (p *FilePrompt) WithExtensions(exts ...string) *FilePrompt {
+	for _, ext := range exts {
+		p.patterns = append(p.patterns, "*."+strings.TrimPrefix(ext, "."))
+	}
+	return p
+}
+
+// WithHidden lists hidden files from the start. They can always be toggled.
+func (p *FilePrompt) WithHidden() *FilePrompt {
+	p.showHidden = true
+	return p
+}
+
+// WithDirectories picks directories instead of files. Enter picks the
+// highlighted directory and the right key opens it.
+func (p *FilePrompt) WithDirectories() *FilePrompt {
+	p.dirsOnly = true
+	return p
+}
+
+// WithMultiSelect lets the user mark several entries with space. Enter picks
+// the marked entries, or the highlighted one if none are marked.
+func (p *FilePrompt) WithMultiSelect() *FilePrompt {
+	p.multi = true
+	return p
+}
+
+// WithHeight shows at most n entries at once and scrolls through the rest.
+func (p *FilePrompt) WithHeight(n int) *FilePrompt {
+	p.list.WithHeight(n)
+	return p
+}
+
+// WithValidator adds a validator every picked path has to pass before it is accepted.
+func (p *FilePrompt) WithValidator(v Validator[string]) *FilePrompt {
+	p.validators = append(p.validators, v)
+	return p
+}
+
+// WithTransform adds a transform that is applied to every picked path before it is validated.
+func (p *FilePrompt) WithTransform(t Transform[string]) *FilePrompt {
+	p.transforms = append(p.transforms, t)
+	return p
+}
+
+// reset clears the answer and starts reading the start directory.
+func (p *FilePrompt) reset() {
+	p.marked = nil
+	p.result = nil
+	p.err = nil
+	p.list.km, p.list.th, p.list.selector = p.km, p.th, p.selector
+
+	dir, err := filepath.Abs(p.start)
+	if err != nil {
+		dir = p.start
+	}
+	p.mu.Lock()
+	p.open(dir)
+	p.mu.Unlock()
+}
+
+func (p *FilePrompt) setRedraw(redraw func()) {
+	p.mu.Lock()
+	p.redraw = redraw
+	p.mu.Unlock()
+}
+
+// parent returns the path of the entry that leads to the parent directory.
+// It returns dir itself at the root.
+func (p *FilePrompt) parent() string {
+	return filepath.Dir(p.dir)
+}
+
+// display renders the entry at path. It is called with mu held.
+func (p *FilePrompt) display(path string) string {
+	switch {
+	case path == p.dir:
+		return "./"
+	case path == p.parent():
+		return "../"
+	case p.dirs[path]:
+		return filepath.Base(path) + "/"
+	}
+	return filepath.Base(path)
+}
+
+// markOf renders whether the entry at path is marked. It is called with mu held.
+func (p *FilePrompt) markOf(path string) string {
+	switch {
+	case !p.multi:
+		return ""
+	case !p.markable(path):
+		return "  "
+	case slices.Contains(p.marked, path):
+		return "◉ "
+	}
+	return "○ "
+}
+
+// markable reports whether the entry at path can be picked. It is called with mu held.
+func (p *FilePrompt) markable(path string) bool {
+	if path == p.parent() {
+		return false
+	}
+	return p.dirs[path] == p.dirsOnly
+}
+
+// listed reports whether the file called name is listed.
+func (p *FilePrompt) listed(name string) bool {
+	if len(p.patterns) == 0 {
+		return true
+	}
+	for _, pattern := range p.patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// open lists the entries of dir. They are read in the background and
+// replace the listed entries once read. It is called with mu held.
+func (p *FilePrompt) open(dir string) {
+	p.generation++
+	generation := p.generation
+	p.dir = dir
+	p.dirs = nil
+	p.loading = true
+	p.readErr = nil
+	p.list.Choices = nil
+	p.list.index = 0
+	p.list.offset = 0
+	p.list.filtering = false
+	p.list.filter = nil
+
+	showHidden, dirsOnly := p.showHidden, p.dirsOnly
+	go func() {
+		entries, err := os.ReadDir(dir)
+
+		var files []string
+		dirs := make(map[string]bool)
+		for _, entry := range entries {
+			name := entry.Name()
+			if !showHidden && strings.HasPrefix(name, ".") {
+				continue
+			}
+			path := filepath.Join(dir, name)
+			isDir := entry.IsDir()
+			if entry.Type()&os.ModeSymlink != 0 {
+				if info, err := os.Stat(path); err == nil {
+					isDir = info.IsDir()
+				}
+			}
+			switch {
+			case isDir:
+				dirs[path] = true
+			case dirsOnly || !p.listed(name):
+				continue
+			default:
+				files = append(files, path)
+			}
+		}
+
+		choices := make([]string, 0, len(dirs)+len(files)+2)
+		if dirsOnly {
+			choices = append(choices, dir)
+		}
+		if parent := filepath.Dir(dir); parent != dir {
+			choices = append(choices, parent)
+		}
+		byName := func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) }
+		sorted := make([]string, 0, len(dirs))
+		for path := range dirs {
+			sorted = append(sorted, path)
+		}
+		slices.SortFunc(sorted, byName)
+		slices.SortFunc(files, byName)
+		choices = append(append(choices, sorted...), files...)
+
+		p.mu.Lock()
+		if generation != p.generation {
+			p.mu.Unlock()
+			return
+		}
+		p.list.Choices = choices
+		p.dirs = dirs
+		p.loading = false
+		p.readErr = err
+		redraw := p.redraw
+		p.mu.Unlock()
+
+		if redraw != nil {
+			redraw()
+		}
+	}()
+}
+
+// highlighted returns the path of the highlighted entry, or false if there is none.
+// It is called with mu held.
+func (p *FilePrompt) highlighted() (string, bool) {
+	if p.loading || len(p.list.Choices) == 0 || !p.list.matches(p.list.index) {
+		return "", false
+	}
+	return p.list.Choices[p.list.index], true
+}
+
+// choose opens the highlighted directory, or picks the highlighted entry and
+// the marked ones. It is called with mu held.
+func (p *FilePrompt) choose() (bool, error) {
+	path, ok := p.highlighted()
+	switch {
+	case !ok:
+		return false, nil
+	case path == p.parent() || (p.dirs[path] && !p.dirsOnly):
+		p.open(path)
+		return false, nil
+	}
+	if p.multi && len(p.marked) > 0 {
+		return p.submit(p.marked...)
+	}
+	return p.submit(path)
+}
+
+// submit accepts paths if all of them pass the validators.
+func (p *FilePrompt) submit(paths ...string) (bool, error) {
+	if len(paths) == 0 {
+		p.err = fmt.Errorf("%w: no paths given", ErrInvalidAnswer)
+		return false, nil
+	}
+	result := make([]string, 0, len(paths))
+	for _, path := range paths {
+		v, err := p.apply(path)
+		if err != nil {
+			p.err = err
+			return false, nil
+		}
+		result = append(result, v)
+	}
+	p.result, p.err = result, nil
+	return true, nil
+}
+
+func (p *FilePrompt) update(key keys.Key) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	km := p.keyMap()
+	p.err = nil
+	if p.list.filtering {
+		if km.Select.command(key) {
+			return p.choose()
+		}
+		return p.list.update(key)
+	}
+
+	switch {
+	case km.Cancel.Matches(key):
+		return false, ErrCanceledPrompt
+	case km.Select.Matches(key):
+		return p.choose()
+	case km.Right.Matches(key):
+		if path, ok := p.highlighted(); ok && p.dirs[path] && path != p.dir {
+			p.open(path)
+		}
+	case km.Left.Matches(key) || key.Code == keys.Backspace:
+		if parent := p.parent(); parent != p.dir {
+			p.open(parent)
+		}
+	case km.Toggle.Matches(key):
+		p.showHidden = !p.showHidden
+		p.open(p.dir)
+	case p.multi && km.Mark.Matches(key):
+		if path, ok := p.highlighted(); ok && p.markable(path) {
+			if i := slices.Index(p.marked, path); i >= 0 {
+				p.marked = slices.Delete(p.marked, i, i+1)
+			} else {
+				p.marked = append(p.marked, path)
+			}
+		}
+	default:
+		return p.list.update(key)
+	}
+	return false, nil
+}
+
+// view renders the label, the directory, its entries, the last validation error and the controls.
+func (p *FilePrompt) view() (string, int, int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t := p.styles()
+	var sb strings.Builder
+	if p.label != "" {
+		sb.WriteString(t.Title.Render(" "+p.label+" ") + "\n\n")
+	}
+	sb.WriteString(t.Accent.Render(p.dir) + "\n")
+	switch {
+	case p.loading:
+		sb.WriteString(t.Muted.Render("   loading…") + "\n")
+	case p.readErr != nil:
+		sb.WriteString(t.Error.Render("   "+p.readErr.Error()) + "\n")
+	}
+	if !p.loading {
+		sb.WriteString(p.list.listView())
+	}
+	if p.multi && len(p.marked) > 0 {
+		sb.WriteString(t.Muted.Render(fmt.Sprintf("   %d marked", len(p.marked))) + "\n")
+	}
+	if p.err != nil {
+		sb.WriteString("\n" + t.Error.Render(p.err.Error()) + "\n")
+	}
+	if !p.hideControls {
+		sb.WriteString("\n" + t.Muted.Render(" "+p.controls()) + "\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), -1, 0
+}
+
+// controls describes the key bindings of the current mode.
+func (p *FilePrompt) controls() string {
+	if p.list.filtering {
+		return p.list.controls()
+	}
+	km := p.keyMap()
+	bindings := []Binding{km.Up, km.Down, km.Select,
+		NewBinding("open", km.Right.Keys...),
+		NewBinding("parent", append(km.Left.Keys, "backspace")...),
+	}
+	if p.multi {
+		bindings = append(bindings, km.Mark)
+	}
+	bindings = append(bindings, km.Filter, NewBinding("hidden files", km.Toggle.Keys...), km.Cancel)
+	return help(bindings...)
+}
+
+// embed hides the controls of the prompt when it is part of a Form.
+func (p *FilePrompt) embed() {
+	p.hideControls = true
+}
+
+func (p *FilePrompt) summary() string {
+	return fmt.Sprintf("%s %s", p.label, strings.Join(p.result, ", "))
+}
+
+// setAnswer accepts a path, or with multi-select several paths separated by
+// the OS path list separator. Relative paths are resolved against the start directory.
+func (p *FilePrompt) setAnswer(answer string) error {
+	answers := []string{answer}
+	if p.multi {
+		answers = filepath.SplitList(answer)
+	}
+	if len(answers) == 0 {
+		return fmt.Errorf("%w: no paths given", ErrInvalidAnswer)
+	}
+
+	dir, err := filepath.Abs(p.start)
+	if err != nil {
+		return err
+	}
+	paths := make([]string, 0, len(answers))
+	for _, a := range answers {
+		path := a
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		info, err := os.Stat(path)
+		switch {
+		case a == "" || err != nil:
+			return fmt.Errorf("%w: %q does not exist", ErrInvalidAnswer, a)
+		case p.dirsOnly && !info.IsDir():
+			return fmt.Errorf("%w: %q is not a directory", ErrInvalidAnswer, a)
+		case !p.dirsOnly && (info.IsDir() || !p.listed(filepath.Base(path))):
+			return fmt.Errorf("%w: %q is not a matching file", ErrInvalidAnswer, a)
+		}
+		paths = append(paths, filepath.Clean(path))
+	}
+	p.submit(paths...)
+	return p.err
+}
+
+func (p *FilePrompt) answer() any {
+	if p.multi {
+		return p.result
+	}
+	if len(p.result) == 0 {
+		return ""
+	}
+	return p.result[0]
+}
+
+// Run starts the FilePrompt and returns the absolute path of the picked entry.
+// A provided answer is returned without user interaction, as is a line read
+// from stdin when stdin is not a terminal.
+func (p *FilePrompt) Run() (string, error) {
+	return p.RunContext(context.Background())
+}
+
+// RunContext is like Run, but gives up when ctx is done. The prompt is
+// removed, the terminal restored and ctx.Err() returned. With multi-select it
+// returns ErrMultiSelect, use RunMultiContext instead.
+func (p *FilePrompt) RunContext(ctx context.Context) (string, error) {
+	if p.multi {
+		return "", ErrMultiSelect
+	}
+	paths, err := p.run(ctx, false)
+	if err != nil {
+		return "", err
+	}
+	return paths[0], nil
+}
+
+// RunMulti starts the FilePrompt with multi-select and returns the absolute
+// paths of the picked entries.
+func (p *FilePrompt) RunMulti() ([]string, error) {
+	return p.RunMultiContext(context.Background())
+}
+
+// RunMultiContext is like RunMulti, but gives up when ctx is done. The prompt
+// is removed, the terminal restored and ctx.Err() returned.
+func (p *FilePrompt) RunMultiContext(ctx context.Context) ([]string, error) {
+	return p.run(ctx, true)
+}
+
+// run runs the prompt with multi-select if multi is set. The prompt keeps the
+// mode set by WithMultiSelect for later runs.
+func (p *FilePrompt) run(ctx context.Context, multi bool) ([]string, error) {
+	defer func(configured bool) { p.multi = configured }(p.multi)
+	p.multi = multi
+	p.reset()
+	if ok, err := scripted(p.id, p, p.interactive()); ok {
+		if err != nil {
+			return nil, err
+		}
+		return p.result, nil
+	}
+
+	out := p.output()
+	out.HideCursor()
+	defer out.ShowCursor()
+	s := newScreen(out)
+	err := run(ctx, &p.termio, s, p)
+	s.clear()
+	if err != nil {
+		return nil, err
+	}
+	s.render(p.summary(), -1, 0)
+	s.end()
+	return p.result, nil
+}
@@ -0,0 +1,490 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"atomicgo.dev/keyboard/keys"
+	"github.com/muesli/termenv"
+	"github.com/stelmanjones/termtools/theme"
+)
+
+// Field is a prompt that can be part of a Form.
+// It is implemented by the prompts of this package.
+type Field interface {
+	model
+	// Label returns the label of the prompt.
+	Label() string
+	// ID returns the ID of the prompt.
+	ID() string
+	reset()
+	answerer
+	// summary renders the label and the accepted answer on a single line.
+	summary() string
+	// answer returns the accepted answer.
+	answer() any
+	// submit accepts the current input as if the select key was pressed.
+	submit() (done bool, err error)
+}
+
+// handler is implemented by fields that use keys of the form themselves at
+// times, such as tab to complete the input.
+type handler interface {
+	// handles reports whether the field takes key instead of the form.
+	handles(key keys.Key) bool
+}
+
+// Answers maps the names of answered form fields to their answers.
+type Answers map[string]any
+
+// FormField is a named prompt inside a Form.
+type FormField struct {
+	Name     string
+	Prompt   Field
+	when     func(Answers) bool
+	answered bool
+}
+
+// NewFormField creates a new FormField. The answer of p is stored under name.
+func NewFormField(name string, p Field) *FormField {
+	return &FormField{
+		Name:   name,
+		Prompt: p,
+	}
+}
+
+// ShowWhen only shows the field if cond returns true for the answers of the fields before it.
+func (f *FormField) ShowWhen(cond func(Answers) bool) *FormField {
+	f.when = cond
+	return f
+}
+
+// Page is a group of fields that are shown together.
+type Page struct {
+	Title  string
+	Fields []*FormField
+}
+
+// Form groups prompts into pages. The user moves between the fields using
+// tab and shift+tab and can go back to change previous answers.
+type Form struct {
+	termio
+	km        *KeyMap
+	th        *theme.Theme
+	pages     []*Page
+	review    bool
+	reviewing bool
+	page      int // index of the current page
+	field     int // index of the focused field on the current page
+}
+
+// NewForm creates a new empty Form.
+func NewForm() *Form {
+	return &Form{
+		pages: make([]*Page, 0),
+	}
+}
+
+// AddPage appends a page with the given title and fields to the form.
+func (f *Form) AddPage(title string, fields ...*FormField) *Form {
+	for _, field := range fields {
+		if e, ok := field.Prompt.(interface{ embed() }); ok {
+			e.embed()
+		}
+	}
+	f.pages = append(f.pages, &Page{Title: title, Fields: fields})
+	return f
+}
+
+// WithReview shows a summary of all answers that has to be confirmed before the form is done.
+func (f *Form) WithReview() *Form {
+	f.review = true
+	return f
+}
+
+// SetInput sets the source of key presses of the form. It replaces the keyboard,
+// and the form no longer falls back to reading stdin when stdin is not a terminal.
+func (f *Form) SetInput(in Input) *Form {
+	f.in = in
+	return f
+}
+
+// SetOutput sets where the form is rendered to. It replaces stdout.
+func (f *Form) SetOutput(w io.Writer) *Form {
+	f.out = termenv.NewOutput(w)
+	return f
+}
+
+// SetTimeout answers the fields that are not answered yet with their defaults
+// once d has passed. The form fails with context.DeadlineExceeded if one of
+// them has no default.
+func (f *Form) SetTimeout(d time.Duration) *Form {
+	f.timeout = d
+	return f
+}
+
+// SetKeyMap sets the key bindings used to move between the fields and to
+// cancel the form, replacing DefaultKeyMap. The fields keep their own key maps.
+func (f *Form) SetKeyMap(km KeyMap) *Form {
+	f.km = &km
+	return f
+}
+
+// keyMap returns the active key map of the form.
+func (f *Form) keyMap() KeyMap {
+	if f.km == nil {
+		return DefaultKeyMap()
+	}
+	return *f.km
+}
+
+// SetTheme sets the theme of the form, replacing theme.Current.
+// It styles the titles and footers of the form, the fields keep their own themes.
+func (f *Form) SetTheme(t *theme.Theme) *Form {
+	f.th = t
+	return f
+}
+
+// styles returns the active theme of the form.
+func (f *Form) styles() *theme.Theme {
+	if f.th == nil {
+		return theme.Current()
+	}
+	return f.th
+}
+
+// scan walks all fields in order and returns which of them are visible
+// together with the answers of the visible, answered fields.
+func (f *Form) scan() (map[*FormField]bool, Answers) {
+	visible := make(map[*FormField]bool)
+	answers := make(Answers)
+	for _, page := range f.pages {
+		for _, field := range page.Fields {
+			if field.when != nil && !field.when(answers) {
+				continue
+			}
+			visible[field] = true
+			if field.answered {
+				answers[field.Name] = field.Prompt.answer()
+			}
+		}
+	}
+	return visible, answers
+}
+
+// next moves the focus to the next visible field.
+// It reports false if there is no field left.
+func (f *Form) next() bool {
+	visible, _ := f.scan()
+	for p := f.page; p < len(f.pages); p++ {
+		start := 0
+		if p == f.page {
+			start = f.field + 1
+		}
+		for i := start; i < len(f.pages[p].Fields); i++ {
+			if visible[f.pages[p].Fields[i]] {
+				f.page, f.field = p, i
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// prev moves the focus to the previous visible field.
+// It reports false if there is no field left.
+func (f *Form) prev() bool {
+	visible, _ := f.scan()
+	for p := f.page; p >= 0; p-- {
+		start := len(f.pages[p].Fields) - 1
+		if p == f.page {
+			start = f.field - 1
+		}
+		for i := start; i >= 0; i-- {
+			if visible[f.pages[p].Fields[i]] {
+				f.page, f.field = p, i
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f *Form) setRedraw(redraw func()) {
+	for _, page := range f.pages {
+		for _, field := range page.Fields {
+			if r, ok := field.Prompt.(redrawer); ok {
+				r.setRedraw(redraw)
+			}
+		}
+	}
+}
+
+func (f *Form) update(key keys.Key) (bool, error) {
+	km := f.keyMap()
+	if km.Cancel.command(key) {
+		return false, ErrCanceledPrompt
+	}
+
+	if f.reviewing {
+		switch {
+		case km.Select.command(key):
+			return true, nil
+		case km.Prev.command(key):
+			f.reviewing = false
+		}
+		return false, nil
+	}
+
+	field := f.pages[f.page].Fields[f.field]
+	var done bool
+	var err error
+	switch h, ok := field.Prompt.(handler); {
+	case ok && h.handles(key):
+		done, err = field.Prompt.update(key)
+	case km.Prev.command(key):
+		f.prev()
+		return false, nil
+	case km.Next.command(key):
+		done, err = field.Prompt.submit()
+	default:
+		done, err = field.Prompt.update(key)
+	}
+	if err != nil || !done {
+		return false, err
+	}
+	field.answered = true
+
+	if f.next() {
+		return false, nil
+	}
+	if f.review {
+		f.reviewing = true
+		return false, nil
+	}
+	return true, nil
+}
+
+func (f *Form) view() (string, int, int) {
+	visible, _ := f.scan()
+	if f.reviewing {
+		return f.reviewView(visible), -1, 0
+	}
+
+	t := f.styles()
+	var sb strings.Builder
+	row, col := -1, 0
+	page := f.pages[f.page]
+	if page.Title != "" {
+		sb.WriteString(t.Title.Render(" "+page.Title+" ") + "\n\n")
+	}
+	for i, field := range page.Fields {
+		switch {
+		case !visible[field]:
+			continue
+		case i == f.field:
+			view, r, c := field.Prompt.view()
+			if r >= 0 {
+				row, col = strings.Count(sb.String(), "\n")+r, c
+			}
+			sb.WriteString(view + "\n")
+		case field.answered:
+			sb.WriteString(t.Muted.Render(field.Prompt.summary()) + "\n")
+		default:
+			sb.WriteString(t.Muted.Render(field.Prompt.Label()) + "\n")
+		}
+	}
+	km := f.keyMap()
+	sb.WriteString("\n" + t.Muted.Render(fmt.Sprintf(" page %d/%d • %s", f.page+1, len(f.pages), help(km.Next, km.Prev, km.Cancel))))
+	return sb.String(), row, col
+}
+
+// reviewView renders the answers of all visible fields grouped by page.
+func (f *Form) reviewView(visible map[*FormField]bool) string {
+	t := f.styles()
+	var sb strings.Builder
+	sb.WriteString(t.Title.Render(" Review ") + "\n")
+	for _, page := range f.pages {
+		var fields strings.Builder
+		for _, field := range page.Fields {
+			if visible[field] {
+				fields.WriteString("   " + field.Prompt.summary() + "\n")
+			}
+		}
+		if fields.Len() == 0 {
+			continue
+		}
+		sb.WriteString("\n")
+		if page.Title != "" {
+			sb.WriteString(t.Accent.Render(" "+page.Title) + "\n")
+		}
+		sb.WriteString(fields.String())
+	}
+	km := f.keyMap()
+	sb.WriteString("\n" + t.Muted.Render(" "+help(
+		NewBinding("submit", km.Select.Keys...),
+		NewBinding("back", km.Prev.Keys...),
+		km.Cancel,
+	)))
+	return sb.String()
+}
+
+// script answers the visible fields that have a provided answer, looked up by
+// the ID of their prompt or else by their name. If the form is not interactive,
+// the remaining visible fields are answered by reading one line per field.
+// It reports whether all visible fields are answered.
+func (f *Form) script() (bool, error) {
+	terminal := f.interactive()
+	all := true
+	answers := make(Answers)
+	for _, page := range f.pages {
+		for _, field := range page.Fields {
+			if field.when != nil && !field.when(answers) {
+				continue
+			}
+
+			id := field.Prompt.ID()
+			if id == "" {
+				id = field.Name
+			}
+			answer, ok := provided(id)
+			if !ok && !terminal {
+				if fallback == FailFast {
+					return false, fmt.Errorf("%s: %w", field.Name, ErrNotTerminal)
+				}
+				line, err := readLine(stdin)
+				if err != nil {
+					return false, fmt.Errorf("%s: %w", field.Name, err)
+				}
+				answer, ok = line, true
+			}
+			if !ok {
+				all = false
+				continue
+			}
+
+			if err := field.Prompt.setAnswer(answer); err != nil {
+				return false, fmt.Errorf("%s: %w", field.Name, err)
+			}
+			field.answered = true
+			answers[field.Name] = field.Prompt.answer()
+		}
+	}
+	return all, nil
+}
+
+// useDefault answers the visible fields that are not answered yet with their defaults.
+func (f *Form) useDefault() error {
+	answers := make(Answers)
+	for _, page := range f.pages {
+		for _, field := range page.Fields {
+			if field.when != nil && !field.when(answers) {
+				continue
+			}
+			if !field.answered {
+				d, ok := field.Prompt.(defaulter)
+				if !ok {
+					return fmt.Errorf("%s: no default", field.Name)
+				}
+				if err := d.useDefault(); err != nil {
+					return fmt.Errorf("%s: %w", field.Name, err)
+				}
+				field.answered = true
+			}
+			answers[field.Name] = field.Prompt.answer()
+		}
+	}
+	return nil
+}
+
+// Run runs the form and returns the answers of all visible fields.
+// If the user cancels the form, the answers given so far are returned together with ErrCanceledPrompt.
+// Fields with a provided answer are answered without user interaction, and
+// if stdin is not a terminal every field is read as a line from stdin.
+func (f *Form) Run() (Answers, error) {
+	return f.RunContext(context.Background())
+}
+
+// RunContext is like Run, but gives up when ctx is done. The prompt is
+// removed, the terminal restored and ctx.Err() returned.
+func (f *Form) RunContext(ctx context.Context) (Answers, error) {
+	for _, page := range f.pages {
+		for _, field := range page.Fields {
+			field.Prompt.reset()
+			field.answered = false
+		}
+	}
+	done, err := f.script()
+	if done || err != nil {
+		_, answers := f.scan()
+		return answers, err
+	}
+
+	// focus the first field that still needs an answer
+	f.page, f.field, f.reviewing = 0, -1, false
+	for f.next() {
+		if !f.pages[f.page].Fields[f.field].answered {
+			break
+		}
+	}
+
+	s := newScreen(f.output())
+	err = run(ctx, &f.termio, s, f)
+	s.clear()
+	_, answers := f.scan()
+	return answers, err
+}
+
+// RunInto runs the form and binds the answers into dst, see Answers.Bind.
+// If the user cancels the form, the answers given so far are bound and ErrCanceledPrompt is returned.
+func (f *Form) RunInto(dst any) error {
+	answers, err := f.Run()
+	if bindErr := answers.Bind(dst); bindErr != nil {
+		return bindErr
+	}
+	return err
+}
+
+// Bind stores the answers in the struct pointed to by dst. A struct field
+// receives the answer of the form field named by its `prompt` tag.
+//
+//	type Config struct {
+//		Name string `prompt:"name"`
+//		Port int    `prompt:"port"`
+//	}
+func (a Answers) Bind(dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return ErrInvalidBindTarget
+	}
+
+	v = v.Elem()
+	t := v.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		name, ok := sf.Tag.Lookup("prompt")
+		if !ok || name == "-" || !sf.IsExported() {
+			continue
+		}
+		answer, ok := a[name]
+		if !ok || answer == nil {
+			continue
+		}
+
+		av := reflect.ValueOf(answer)
+		fv := v.Field(i)
+		switch {
+		case av.Type().AssignableTo(fv.Type()):
+			fv.Set(av)
+		case av.Kind() == fv.Kind() && av.Type().ConvertibleTo(fv.Type()):
+			fv.Set(av.Convert(fv.Type()))
+		default:
+			return fmt.Errorf("%w: %s into %s.%s", ErrBindType, av.Type(), t.Name(), sf.Name)
+		}
+	}
+	return nil
+}
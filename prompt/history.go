@@ -0,0 +1,155 @@
+package prompt
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// DefaultHistorySize is the number of answers kept per prompt when a history
+// is created with a size of zero.
+const DefaultHistorySize = 500
+
+// History stores the previous answers of question prompts by prompt ID, so
+// they can be recalled with the up and down keys. Answers of password prompts
+// are never recorded.
+type History interface {
+	// Entries returns the answers stored for id, oldest first.
+	Entries(id string) ([]string, error)
+	// Add stores answer for id as the newest entry.
+	Add(id, answer string) error
+}
+
+var history History
+
+// SetHistory sets the history used by question prompts that don't have one of their own.
+func SetHistory(h History) {
+	history = h
+}
+
+// addEntry appends answer to entries, removes an earlier copy of it and
+// drops the oldest entries beyond size.
+func addEntry(entries []string, answer string, size int) []string {
+	entries = slices.DeleteFunc(entries, func(e string) bool { return e == answer })
+	entries = append(entries, answer)
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+	if len(entries) > size {
+		entries = entries[len(entries)-size:]
+	}
+	return entries
+}
+
+// MemoryHistory is a History that lives as long as the program.
+type MemoryHistory struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string][]string
+}
+
+// NewMemoryHistory creates a MemoryHistory that keeps the last size answers
+// of every prompt, or DefaultHistorySize if size is zero.
+func NewMemoryHistory(size int) *MemoryHistory {
+	return &MemoryHistory{
+		size:    size,
+		entries: make(map[string][]string),
+	}
+}
+
+// Entries returns the answers stored for id, oldest first.
+func (h *MemoryHistory) Entries(id string) ([]string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.entries[id]), nil
+}
+
+// Add stores answer for id as the newest entry.
+func (h *MemoryHistory) Add(id, answer string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries[id] = addEntry(h.entries[id], answer, h.size)
+	return nil
+}
+
+// FileHistory is a History that is kept in a JSON file, mapping prompt IDs to
+// their answers. The file is only readable by its owner.
+type FileHistory struct {
+	mu   sync.Mutex
+	path string
+	size int
+}
+
+// NewFileHistory creates a FileHistory stored at path that keeps the last size
+// answers of every prompt, or DefaultHistorySize if size is zero.
+// The file is created when the first answer is added.
+func NewFileHistory(path string, size int) *FileHistory {
+	return &FileHistory{
+		path: path,
+		size: size,
+	}
+}
+
+// read returns the answers of all prompts stored in the file.
+func (h *FileHistory) read() (map[string][]string, error) {
+	entries := make(map[string][]string)
+	data, err := os.ReadFile(h.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Entries returns the answers stored for id, oldest first.
+func (h *FileHistory) Entries(id string) ([]string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entries, err := h.read()
+	if err != nil {
+		return nil, err
+	}
+	return entries[id], nil
+}
+
+// Add stores answer for id as the newest entry. The file is replaced
+// atomically, so a crash can't leave it half written.
+func (h *FileHistory) Add(id, answer string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entries, err := h.read()
+	if err != nil {
+		return err
+	}
+	entries[id] = addEntry(entries[id], answer, h.size)
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(h.path), filepath.Base(h.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), h.path)
+}
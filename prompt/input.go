@@ -1,11 +1,22 @@
 package prompt
 
 import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
 	"atomicgo.dev/keyboard"
 	"atomicgo.dev/keyboard/keys"
+	"github.com/muesli/termenv"
+	"github.com/stelmanjones/termtools/tty/keypress"
+	"golang.org/x/term"
 )
 
 // ListenForInput listens for input from the user.
+//
+// Deprecated: ListenForInput can't be stopped and keeps sending to ch after
+// the caller is done with it. Use ListenForInputContext instead.
 func ListenForInput(ch chan keys.Key) error {
 	return keyboard.Listen(func(key keys.Key) (stop bool, err error) {
 		switch key.Code {
@@ -26,3 +37,214 @@ func ListenForInput(ch chan keys.Key) error {
 		}
 	})
 }
+
+// ListenForInputContext sends key presses to ch until Enter, Ctrl+C, Ctrl+D or
+// Esc is pressed or ctx is done, in which case it returns ctx.Err(). It owns ch
+// and closes it when it returns, so ch must not be closed by the caller.
+func ListenForInputContext(ctx context.Context, ch chan<- keys.Key) error {
+	defer close(ch)
+	var l keypress.Listener
+	stop := context.AfterFunc(ctx, l.Interrupt)
+	defer stop()
+
+	err := l.Listen(func(key keys.Key) (bool, error) {
+		if ctx.Err() != nil {
+			return true, nil
+		}
+		if key.Code == keys.Null {
+			return false, nil
+		}
+		select {
+		case ch <- key:
+		case <-ctx.Done():
+			return true, nil
+		}
+		switch key.Code {
+		case keys.Enter, keys.CtrlC, keys.CtrlD, keys.Esc:
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// Input is a source of key presses for prompts. By default prompts read the
+// keyboard; tests can replace it, see the prompttest package.
+type Input interface {
+	// Listen calls onKey for every key press until onKey returns true or an error.
+	Listen(onKey func(key keys.Key) (stop bool, err error)) error
+}
+
+// Interrupter is implemented by inputs that can stop waiting for a key press.
+// When the context of a prompt is done, Interrupt is called and the input is
+// expected to call onKey once more, so Listen can return. Inputs that don't
+// implement it are stopped by the next key press.
+type Interrupter interface {
+	Interrupt()
+}
+
+// keyboardInput reads key presses from the terminal. It listens once.
+type keyboardInput struct {
+	l keypress.Listener
+}
+
+func (k *keyboardInput) Listen(onKey func(key keys.Key) (stop bool, err error)) error {
+	return k.l.Listen(onKey)
+}
+
+// Interrupt wakes up Listen with a simulated null key press, which lets it
+// restore the terminal and return. It does nothing once Listen returned.
+func (k *keyboardInput) Interrupt() {
+	k.l.Interrupt()
+}
+
+// termio holds the input and output used by a prompt.
+type termio struct {
+	in      Input
+	out     *termenv.Output
+	timeout time.Duration
+}
+
+// source returns the input of the prompt, which defaults to the keyboard.
+func (t *termio) source() Input {
+	if t.in == nil {
+		return &keyboardInput{}
+	}
+	return t.in
+}
+
+// output returns the output of the prompt, which defaults to stdout.
+func (t *termio) output() *termenv.Output {
+	if t.out == nil {
+		return termenv.DefaultOutput()
+	}
+	return t.out
+}
+
+// defaultColumns is the width of the output of a prompt that is not a terminal.
+const defaultColumns = 80
+
+// columns returns the width of the output of the prompt, or defaultColumns
+// if it is not a terminal, so recorded output doesn't depend on the terminal
+// that runs the program.
+func (t *termio) columns() int {
+	if f := t.output().TTY(); f != nil {
+		if w, _, err := term.GetSize(int(f.Fd())); err == nil && w > 0 {
+			return w
+		}
+	}
+	return defaultColumns
+}
+
+// interactive reports whether the prompt can ask the user. This is the case
+// if its input was replaced or stdin is a terminal.
+func (t *termio) interactive() bool {
+	return t.in != nil || isTerminal()
+}
+
+// model is implemented by prompts that are driven one key press at a time.
+// It lets Run and Form share the same input loop.
+type model interface {
+	// update handles a single key press. It reports true once the prompt is
+	// answered and returns ErrCanceledPrompt if the user cancels it.
+	update(key keys.Key) (done bool, err error)
+	// view renders the prompt and returns the cursor position within the view.
+	// A negative row leaves the cursor at the end of the view.
+	view() (view string, row, col int)
+}
+
+// defaulter is implemented by models that can be answered with their default
+// when they time out.
+type defaulter interface {
+	useDefault() error
+}
+
+// redrawer is implemented by models that change outside of update, for
+// example when a background completion finishes. run passes them a function
+// that redraws the prompt.
+type redrawer interface {
+	setRedraw(redraw func())
+}
+
+// errTimeout is the cause of the context of a prompt that timed out.
+var errTimeout = errors.New("prompt timed out")
+
+// run draws m on s and feeds it key presses from the input of t until it is
+// answered or canceled, or ctx is done. If the timeout of t passes first, m is
+// answered with its default.
+func run(ctx context.Context, t *termio, s *screen, m model) error {
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, t.timeout, errTimeout)
+		defer cancel()
+	}
+	in := t.source()
+
+	var mu sync.Mutex
+	var finished bool
+	var err error
+
+	if r, ok := m.(redrawer); ok {
+		r.setRedraw(func() {
+			mu.Lock()
+			defer mu.Unlock()
+			if !finished {
+				s.render(m.view())
+			}
+		})
+		defer r.setRedraw(nil)
+	}
+
+	// wake up the input when ctx is done, unless m is already finished
+	stop := context.AfterFunc(ctx, func() {
+		mu.Lock()
+		interrupt := !finished
+		mu.Unlock()
+		if i, ok := in.(Interrupter); ok && interrupt {
+			i.Interrupt()
+		}
+	})
+	defer stop()
+
+	mu.Lock()
+	s.render(m.view())
+	mu.Unlock()
+	answered := false
+	listenErr := in.Listen(func(key keys.Key) (stop bool, _ error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if ctx.Err() != nil {
+			finished = true
+			return true, nil
+		}
+		if key.Code == keys.Null {
+			return false, nil // the wake up call of an interrupt
+		}
+		answered, err = m.update(key)
+		if answered || err != nil {
+			finished = true
+			return true, nil
+		}
+		s.render(m.view())
+		return false, nil
+	})
+
+	mu.Lock()
+	finished = true
+	mu.Unlock()
+	switch {
+	case listenErr != nil:
+		return listenErr
+	case answered || err != nil:
+		return err
+	case ctx.Err() != nil:
+		if d, ok := m.(defaulter); ok && context.Cause(ctx) == errTimeout && d.useDefault() == nil {
+			return nil
+		}
+		return ctx.Err()
+	}
+	return nil
+}
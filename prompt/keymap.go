@@ -0,0 +1,193 @@
+package prompt
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"atomicgo.dev/keyboard/keys"
+)
+
+// Binding binds one or more keys to an action of a prompt.
+type Binding struct {
+	// Keys are the names of the bound keys as returned by keys.Key.String,
+	// for example "enter", "ctrl+c", "shift+tab" or "j".
+	Keys []string
+	// Help describes the action in the help footer.
+	Help string
+}
+
+// NewBinding creates a new Binding of the given keys described by help.
+func NewBinding(help string, keys ...string) Binding {
+	return Binding{Keys: keys, Help: help}
+}
+
+// Matches reports whether key is bound.
+func (b Binding) Matches(key keys.Key) bool {
+	return slices.Contains(b.Keys, key.String())
+}
+
+// Enabled reports whether any key is bound. Disabled bindings are left out of the help footer.
+func (b Binding) Enabled() bool {
+	return len(b.Keys) > 0
+}
+
+// command is like Matches, but never matches printable characters, so they
+// can still be typed into text prompts and filters. Characters typed with alt,
+// such as "alt+v", are commands.
+func (b Binding) command(key keys.Key) bool {
+	if (key.Code == keys.RuneKey && !key.AltPressed) || key.Code == keys.Space {
+		return false
+	}
+	return b.Matches(key)
+}
+
+// commands returns the binding without the keys of printable characters,
+// which are typed into text prompts and filters instead.
+func (b Binding) commands() Binding {
+	c := Binding{Help: b.Help}
+	for _, k := range b.Keys {
+		if utf8.RuneCountInString(k) > 1 {
+			c.Keys = append(c.Keys, k)
+		}
+	}
+	return c
+}
+
+// without returns the binding without the keys that are bound by other.
+func (b Binding) without(other Binding) Binding {
+	c := Binding{Help: b.Help}
+	for _, k := range b.Keys {
+		if !slices.Contains(other.Keys, k) {
+			c.Keys = append(c.Keys, k)
+		}
+	}
+	return c
+}
+
+// keySymbols are the symbols shown in the help footer instead of key names.
+var keySymbols = map[string]string{
+	"up":        "↑",
+	"down":      "↓",
+	"left":      "←",
+	"right":     "→",
+	"shift+tab": "S-tab",
+}
+
+// help renders the enabled bindings as a single line, e.g. "↑/k: up • enter: select".
+func help(bindings ...Binding) string {
+	parts := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		if !b.Enabled() {
+			continue
+		}
+		names := make([]string, len(b.Keys))
+		for i, k := range b.Keys {
+			if s, ok := keySymbols[k]; ok {
+				k = s
+			}
+			names[i] = k
+		}
+		parts = append(parts, strings.Join(names, "/")+": "+b.Help)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// KeyMap holds the key bindings of the actions of a prompt.
+// Not every prompt uses every action.
+type KeyMap struct {
+	Up       Binding // moves the highlight up
+	Down     Binding // moves the highlight down
+	Left     Binding // moves the highlight left
+	Right    Binding // moves the highlight right
+	PrevPage Binding // moves a page back, or a month in a date prompt
+	NextPage Binding // moves a page forward, or a month in a date prompt
+	Select   Binding // submits the answer
+	Cancel   Binding // cancels the prompt
+	Yes      Binding // answers a confirmation with yes
+	No       Binding // answers a confirmation with no
+	Toggle   Binding // reveals a password or the hidden files of a file prompt
+	Filter   Binding // starts filtering the choices of a selection prompt
+	Mark     Binding // marks a choice of a prompt that accepts several
+	Search   Binding // searches the answer history of a question prompt
+	Complete Binding // accepts the highlighted suggestion
+	Next     Binding // submits the field of a form and moves to the next one
+	Prev     Binding // moves to the previous field of a form
+	Submit   Binding // submits a multi-line editor, where enter inserts a line break
+	External Binding // opens the text of an editor prompt in $EDITOR
+}
+
+// DefaultKeyMap returns the key map used by prompts unless another one is set.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:       NewBinding("up", "up", "shift+tab", "k"),
+		Down:     NewBinding("down", "down", "tab", "j"),
+		Left:     NewBinding("left", "left", "h"),
+		Right:    NewBinding("right", "right", "l"),
+		PrevPage: NewBinding("previous page", "pgup", "["),
+		NextPage: NewBinding("next page", "pgdown", "]"),
+		Select:   NewBinding("select", "enter"),
+		Cancel:   NewBinding("cancel", "ctrl+c", "ctrl+d", "esc"),
+		Yes:      NewBinding("yes", "y", "Y"),
+		No:       NewBinding("no", "n", "N"),
+		Toggle:   NewBinding("reveal", "ctrl+r"),
+		Filter:   NewBinding("filter", "/"),
+		Mark:     NewBinding("mark", "space"),
+		Search:   NewBinding("search history", "ctrl+r"),
+		Complete: NewBinding("complete", "tab"),
+		Next:     NewBinding("next field", "tab"),
+		Prev:     NewBinding("previous field", "shift+tab"),
+		Submit:   NewBinding("submit", "ctrl+d"),
+		External: NewBinding("open in $EDITOR", "ctrl+e"),
+	}
+}
+
+// VimKeyMap returns a key map with vim style bindings.
+func VimKeyMap() KeyMap {
+	return KeyMap{
+		Up:       NewBinding("up", "k", "up"),
+		Down:     NewBinding("down", "j", "down"),
+		Left:     NewBinding("left", "h", "left"),
+		Right:    NewBinding("right", "l", "right"),
+		PrevPage: NewBinding("previous page", "ctrl+b", "pgup"),
+		NextPage: NewBinding("next page", "ctrl+f", "pgdown"),
+		Select:   NewBinding("select", "enter"),
+		Cancel:   NewBinding("quit", "q", "esc", "ctrl+c"),
+		Yes:      NewBinding("yes", "y", "Y"),
+		No:       NewBinding("no", "n", "N"),
+		Toggle:   NewBinding("reveal", "ctrl+r"),
+		Filter:   NewBinding("filter", "/"),
+		Mark:     NewBinding("mark", "space"),
+		Search:   NewBinding("search history", "ctrl+r"),
+		Complete: NewBinding("complete", "tab", "ctrl+n"),
+		Next:     NewBinding("next field", "tab"),
+		Prev:     NewBinding("previous field", "shift+tab"),
+		Submit:   NewBinding("submit", "ctrl+d"),
+		External: NewBinding("open in $EDITOR", "ctrl+e"),
+	}
+}
+
+// EmacsKeyMap returns a key map with emacs style bindings.
+func EmacsKeyMap() KeyMap {
+	return KeyMap{
+		Up:       NewBinding("up", "ctrl+p", "up"),
+		Down:     NewBinding("down", "ctrl+n", "down"),
+		Left:     NewBinding("left", "ctrl+b", "left"),
+		Right:    NewBinding("right", "ctrl+f", "right"),
+		PrevPage: NewBinding("previous page", "alt+v", "pgup"),
+		NextPage: NewBinding("next page", "ctrl+v", "pgdown"),
+		Select:   NewBinding("select", "enter", "ctrl+j"),
+		Cancel:   NewBinding("quit", "ctrl+g", "ctrl+c"),
+		Yes:      NewBinding("yes", "y", "Y"),
+		No:       NewBinding("no", "n", "N"),
+		Toggle:   NewBinding("reveal", "ctrl+t"),
+		Filter:   NewBinding("search", "ctrl+s"),
+		Mark:     NewBinding("mark", "space"),
+		Search:   NewBinding("search history", "ctrl+r"),
+		Complete: NewBinding("complete", "tab", "alt+/"),
+		Next:     NewBinding("next field", "tab"),
+		Prev:     NewBinding("previous field", "shift+tab"),
+		Submit:   NewBinding("submit", "ctrl+d"),
+		External: NewBinding("open in $EDITOR", "ctrl+x"),
+	}
+}
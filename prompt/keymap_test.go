@@ -0,0 +1,92 @@
+package prompt
+
+import (
+	"testing"
+
+	"atomicgo.dev/keyboard/keys"
+)
+
+// alt returns the key press of r typed with alt.
+func alt(r rune) keys.Key {
+	return keys.Key{Code: keys.RuneKey, Runes: []rune{r}, AltPressed: true}
+}
+
+func TestBindingCommand(t *testing.T) {
+	b := NewBinding("complete", "tab", "alt+/")
+	if !b.command(alt('/')) {
+		t.Error("alt+/ is not a command")
+	}
+	if b.command(keys.Key{Code: keys.RuneKey, Runes: []rune{'/'}}) {
+		t.Error("/ is a command, it should be typed")
+	}
+	if !b.command(keys.Key{Code: keys.Tab}) {
+		t.Error("tab is not a command")
+	}
+}
+
+func TestEmacsComplete(t *testing.T) {
+	p := NewQuestionPrompt("Name")
+	p.SetKeyMap(EmacsKeyMap())
+	p.reset()
+	p.input = []rune("go")
+	p.suggestions = []string{"gopher"}
+
+	if _, err := p.update(alt('/')); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(p.input); got != "gopher" {
+		t.Errorf("input is %q after alt+/, want %q", got, "gopher")
+	}
+}
+
+func TestEmacsPrevPage(t *testing.T) {
+	p := NewSelectionPrompt("a", "b", "c", "d", "e", "f", "g")
+	p.WithHeight(3)
+	p.SetKeyMap(EmacsKeyMap())
+	p.reset()
+
+	for _, key := range []keys.Key{
+		{Code: keys.CtrlS}, // filter, where only commands move the list
+		{Code: keys.CtrlV},
+		alt('v'),
+	} {
+		if _, err := p.update(key); err != nil {
+			t.Fatal(err)
+		}
+		if key.Code == keys.CtrlV && p.index != 3 {
+			t.Fatalf("index is %d after ctrl+v, want 3", p.index)
+		}
+	}
+	if p.index != 0 {
+		t.Errorf("index is %d after alt+v, want 0", p.index)
+	}
+	if len(p.filter) != 0 {
+		t.Errorf("alt+v was typed into the filter %q", string(p.filter))
+	}
+}
+
+func TestFormComplete(t *testing.T) {
+	name := NewQuestionPrompt("Name").WithCompleter(func(string) []string { return nil })
+	f := NewForm().AddPage("",
+		NewFormField("name", name),
+		NewFormField("color", NewQuestionPrompt("Color")),
+	)
+	name.reset()
+	f.page, f.field = 0, 0
+	name.input = []rune("go")
+	name.suggestions = []string{"gopher"}
+
+	tab := keys.Key{Code: keys.Tab}
+	if _, err := f.update(tab); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(name.input); got != "gopher" || f.field != 0 {
+		t.Fatalf("input is %q on field %d after tab, want %q on field 0", got, f.field, "gopher")
+	}
+	if _, err := f.update(tab); err != nil {
+		t.Fatal(err)
+	}
+	if f.field != 1 {
+		t.Errorf("tab without suggestions stayed on field %d, want field 1", f.field)
+	}
+}
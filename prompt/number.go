@@ -0,0 +1,290 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"atomicgo.dev/keyboard/keys"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/exp/constraints"
+)
+
+// Number is a type constraint for the values of a NumberPrompt.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// NumberPrompt is a prompt for a number. The number can be typed or changed
+// by step using the up and down keys, and has to lie within an optional range.
+type NumberPrompt[T Number] struct {
+	Base[T]
+	validation[T]
+	defaultValue T
+	min, max     T
+	bounded      bool
+	step         T
+	input        []rune
+	result       T
+}
+
+// NewNumberPrompt creates a new NumberPrompt with the provided label.
+func NewNumberPrompt[T Number](label string) *NumberPrompt[T] {
+	p := &NumberPrompt[T]{
+		Base: Base[T]{
+			label: label,
+		},
+		step: 1,
+	}
+	return p
+}
+
+// SetLabel sets the label of the NumberPrompt.
+func (p *NumberPrompt[T]) SetLabel(label string) {
+	p.label = label
+}
+
+// SetDefault sets the number the prompt starts with.
+func (p *NumberPrompt[T]) SetDefault(v T) {
+	p.defaultValue = v
+}
+
+// WithRange only accepts numbers between min and max, inclusive.
+func (p *NumberPrompt[T]) WithRange(min, max T) *NumberPrompt[T] {
+	p.min, p.max = min, max
+	p.bounded = true
+	return p
+}
+
+// WithStep sets the amount the number changes by when pressing up or down.
+func (p *NumberPrompt[T]) WithStep(step T) *NumberPrompt[T] {
+	p.step = step
+	return p
+}
+
+// WithValidator adds a validator the number has to pass before it is accepted.
+func (p *NumberPrompt[T]) WithValidator(v Validator[T]) *NumberPrompt[T] {
+	p.validators = append(p.validators, v)
+	return p
+}
+
+// WithTransform adds a transform that is applied to the number before it is validated.
+func (p *NumberPrompt[T]) WithTransform(t Transform[T]) *NumberPrompt[T] {
+	p.transforms = append(p.transforms, t)
+	return p
+}
+
+func (p *NumberPrompt[T]) reset() {
+	p.input = []rune(p.format(p.defaultValue))
+	p.result = *new(T)
+	p.err = nil
+}
+
+// kind returns the reflect.Kind of T.
+func (p *NumberPrompt[T]) kind() reflect.Kind {
+	return reflect.TypeFor[T]().Kind()
+}
+
+// format returns v as text without trailing zeros.
+func (p *NumberPrompt[T]) format(v T) string {
+	switch p.kind() {
+	case reflect.Float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(float64(v), 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// parse parses s as a number of type T.
+func (p *NumberPrompt[T]) parse(s string) (T, error) {
+	s = strings.TrimSpace(s)
+	t := reflect.TypeFor[T]()
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return 0, errors.New("must be a number")
+		}
+		return T(f), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return 0, errors.New("must be a whole number of zero or more")
+		}
+		return T(u), nil
+	default:
+		i, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return 0, errors.New("must be a whole number")
+		}
+		return T(i), nil
+	}
+}
+
+// check parses s and checks that the number lies within the range.
+func (p *NumberPrompt[T]) check(s string) (T, error) {
+	v, err := p.parse(s)
+	if err != nil {
+		return v, err
+	}
+	if p.bounded && (v < p.min || v > p.max) {
+		return v, fmt.Errorf("must be between %s and %s", p.format(p.min), p.format(p.max))
+	}
+	return v, nil
+}
+
+// increment changes the number by n steps and keeps it within the range.
+// Invalid input is replaced by the default first.
+func (p *NumberPrompt[T]) increment(n int) {
+	v, err := p.parse(string(p.input))
+	if err != nil {
+		v = p.defaultValue
+	}
+
+	var next T
+	switch p.kind() {
+	case reflect.Float32, reflect.Float64:
+		// round to the precision of the step, so repeated steps don't accumulate errors
+		decimals := 0
+		if _, frac, ok := strings.Cut(p.format(p.step), "."); ok {
+			decimals = len(frac)
+		}
+		scale := math.Pow10(decimals)
+		next = T(math.Round((float64(v)+float64(n)*float64(p.step))*scale) / scale)
+	default:
+		switch {
+		case n > 0 && v > v+p.step:
+			next = v // overflow
+		case n < 0 && v < v-p.step:
+			next = v // underflow
+		case n > 0:
+			next = v + p.step
+		default:
+			next = v - p.step
+		}
+	}
+	if p.bounded {
+		next = min(max(next, p.min), p.max)
+	}
+	p.input = []rune(p.format(next))
+}
+
+func (p *NumberPrompt[T]) update(key keys.Key) (bool, error) {
+	km := p.keyMap()
+	switch {
+	case km.Cancel.command(key):
+		return false, ErrCanceledPrompt
+
+	case km.Select.command(key):
+		return p.submit()
+
+	case km.Up.command(key):
+		p.increment(1)
+
+	case km.Down.command(key):
+		p.increment(-1)
+
+	case key.Code == keys.Backspace:
+		if len(p.input) > 0 {
+			p.input = p.input[:len(p.input)-1]
+		}
+
+	default:
+		p.input = append(p.input, key.Runes...)
+	}
+	p.err = nil
+	return false, nil
+}
+
+// submit accepts the number if it is valid and within the range.
+func (p *NumberPrompt[T]) submit() (bool, error) {
+	v, err := p.check(string(p.input))
+	if err != nil {
+		p.err = err
+		return false, nil
+	}
+	p.result, p.err = p.apply(v)
+	return p.err == nil, nil
+}
+
+// line returns the label followed by value.
+func (p *NumberPrompt[T]) line(value string) string {
+	if p.label == "" {
+		return value
+	}
+	return p.label + " " + value
+}
+
+// view renders the label, the input, the range and the last validation error.
+func (p *NumberPrompt[T]) view() (string, int, int) {
+	t := p.styles()
+	line := p.line(string(p.input))
+	view := line
+	if p.bounded {
+		view += t.Muted.Render(fmt.Sprintf(" (%s–%s)", p.format(p.min), p.format(p.max)))
+	}
+	if p.err != nil {
+		view += "\n" + t.Error.Render(p.err.Error())
+	}
+	return view, 0, lipgloss.Width(line)
+}
+
+func (p *NumberPrompt[T]) summary() string {
+	return p.line(p.format(p.result))
+}
+
+// setAnswer accepts a number as text. An empty answer selects the default.
+func (p *NumberPrompt[T]) setAnswer(answer string) error {
+	if strings.TrimSpace(answer) == "" {
+		answer = p.format(p.defaultValue)
+	}
+	v, err := p.check(answer)
+	if err != nil {
+		return fmt.Errorf("%w: %q %v", ErrInvalidAnswer, answer, err)
+	}
+	p.result, p.err = p.apply(v)
+	return p.err
+}
+
+// useDefault answers the prompt with its default.
+func (p *NumberPrompt[T]) useDefault() error {
+	return p.setAnswer("")
+}
+
+func (p *NumberPrompt[T]) answer() any {
+	return p.result
+}
+
+// Run starts the NumberPrompt and returns the number.
+// Enter is refused until the number is within the range and passes all validators.
+// A provided answer is returned without user interaction, as is a line read
+// from stdin when stdin is not a terminal.
+func (p *NumberPrompt[T]) Run() (T, error) {
+	return p.RunContext(context.Background())
+}
+
+// RunContext is like Run, but gives up when ctx is done. The prompt is
+// removed, the terminal restored and ctx.Err() returned.
+func (p *NumberPrompt[T]) RunContext(ctx context.Context) (T, error) {
+	p.reset()
+	if ok, err := scripted(p.id, p, p.interactive()); ok {
+		if err != nil {
+			return 0, err
+		}
+		return p.result, nil
+	}
+
+	s := newScreen(p.output())
+	if err := run(ctx, &p.termio, s, p); err != nil {
+		s.clear()
+		return 0, err
+	}
+	s.render(p.summary(), -1, 0)
+	s.end()
+	return p.result, nil
+}
@@ -0,0 +1,258 @@
+package prompt
+
+import (
+	"context"
+	"strings"
+
+	"atomicgo.dev/keyboard/keys"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PasswordPrompt is a prompt for secrets. The input is masked or not echoed at all.
+type PasswordPrompt struct {
+	Base[string]
+	validation[string]
+	mask             rune // 0 disables echo
+	confirmLabel     string
+	confirm          bool
+	revealable       bool
+	revealed         bool
+	allowNonTerminal bool
+	confirming       bool
+	input            []rune
+	first            []rune
+	result           string
+}
+
+// NewPasswordPrompt creates a new PasswordPrompt with the provided label.
+// The input is masked with '*' by default.
+func NewPasswordPrompt(label string) *PasswordPrompt {
+	p := &PasswordPrompt{
+		Base: Base[string]{
+			label: label,
+		},
+		mask: '*',
+	}
+	return p
+}
+
+// SetLabel sets the label of the PasswordPrompt.
+func (p *PasswordPrompt) SetLabel(label string) {
+	p.label = label
+}
+
+// WithMask sets the character that is echoed for every typed character.
+func (p *PasswordPrompt) WithMask(mask rune) *PasswordPrompt {
+	p.mask = mask
+	return p
+}
+
+// NoEcho disables echoing of the input.
+func (p *PasswordPrompt) NoEcho() *PasswordPrompt {
+	p.mask = 0
+	return p
+}
+
+// WithReveal allows the user to toggle between the masked and the plain input
+// using the Toggle binding, ctrl+r by default.
+func (p *PasswordPrompt) WithReveal() *PasswordPrompt {
+	p.revealable = true
+	return p
+}
+
+// WithConfirmation asks the user to enter the secret a second time using label.
+// Both entries have to match before the secret is accepted.
+func (p *PasswordPrompt) WithConfirmation(label string) *PasswordPrompt {
+	p.confirm = true
+	p.confirmLabel = label
+	return p
+}
+
+// AllowNonTerminal allows the prompt to read a line from stdin when stdin is not a terminal.
+func (p *PasswordPrompt) AllowNonTerminal() *PasswordPrompt {
+	p.allowNonTerminal = true
+	return p
+}
+
+// WithValidator adds a validator the secret has to pass before it is accepted.
+func (p *PasswordPrompt) WithValidator(v Validator[string]) *PasswordPrompt {
+	p.validators = append(p.validators, v)
+	return p
+}
+
+// WithTransform adds a transform that is applied to the secret before it is validated.
+func (p *PasswordPrompt) WithTransform(t Transform[string]) *PasswordPrompt {
+	p.transforms = append(p.transforms, t)
+	return p
+}
+
+func (p *PasswordPrompt) reset() {
+	wipe(p.input)
+	wipe(p.first)
+	p.input, p.first = nil, nil
+	p.confirming = false
+	p.revealed = false
+	p.result = ""
+	p.err = nil
+}
+
+func (p *PasswordPrompt) update(key keys.Key) (bool, error) {
+	km := p.keyMap()
+	switch {
+	case km.Cancel.command(key):
+		return false, ErrCanceledPrompt
+
+	case km.Toggle.command(key):
+		if p.revealable {
+			p.revealed = !p.revealed
+		}
+		return false, nil
+
+	case km.Select.command(key):
+		return p.submit()
+
+	case key.Code == keys.Backspace:
+		if len(p.input) > 0 {
+			p.input[len(p.input)-1] = 0
+			p.input = p.input[:len(p.input)-1]
+		}
+
+	default:
+		p.input = appendSecret(p.input, key.Runes)
+	}
+	p.err = nil
+	return false, nil
+}
+
+// submit accepts the secret, or asks for its confirmation first if enabled.
+func (p *PasswordPrompt) submit() (bool, error) {
+	if !p.confirming {
+		p.result, p.err = p.apply(string(p.input))
+		if p.err != nil || !p.confirm {
+			return p.err == nil, nil
+		}
+		p.first, p.input = p.input, nil
+		p.confirming = true
+		return false, nil
+	}
+
+	if string(p.first) != string(p.input) {
+		p.reset()
+		p.err = ErrPasswordMismatch
+		return false, nil
+	}
+	return true, nil
+}
+
+// view renders the label of the current entry, the masked input and the last validation error.
+func (p *PasswordPrompt) view() (string, int, int) {
+	label := p.label
+	if p.confirming {
+		label = p.confirmLabel
+	}
+
+	var line string
+	switch {
+	case p.revealed:
+		line = string(p.input)
+	case p.mask != 0:
+		line = strings.Repeat(string(p.mask), len(p.input))
+	}
+	if label != "" {
+		line = label + " " + line
+	}
+
+	view := line
+	if p.err != nil {
+		view += "\n" + p.styles().Error.Render(p.err.Error())
+	}
+	return view, 0, lipgloss.Width(line)
+}
+
+// summary shows the label with a fixed length mask, so the length of the secret is not revealed.
+func (p *PasswordPrompt) summary() string {
+	mask := p.mask
+	if mask == 0 {
+		mask = '*'
+	}
+	return p.label + " " + strings.Repeat(string(mask), 8)
+}
+
+func (p *PasswordPrompt) setAnswer(answer string) error {
+	p.result, p.err = p.apply(answer)
+	return p.err
+}
+
+func (p *PasswordPrompt) answer() any {
+	return p.result
+}
+
+// Run starts the PasswordPrompt and returns the secret.
+// A provided answer is returned without user interaction. Otherwise it returns
+// ErrNotTerminal if stdin is not a terminal, unless AllowNonTerminal is set.
+func (p *PasswordPrompt) Run() (string, error) {
+	return p.RunContext(context.Background())
+}
+
+// RunContext is like Run, but gives up when ctx is done. The prompt is
+// removed, the terminal restored and ctx.Err() returned.
+func (p *PasswordPrompt) RunContext(ctx context.Context) (string, error) {
+	p.reset()
+	if answer, ok := provided(p.id); ok {
+		if err := p.setAnswer(answer); err != nil {
+			return "", err
+		}
+		return p.result, nil
+	}
+	if !p.interactive() {
+		if !p.allowNonTerminal {
+			return "", ErrNotTerminal
+		}
+		return p.readLines()
+	}
+
+	s := newScreen(p.output())
+	defer p.reset()
+	err := run(ctx, &p.termio, s, p)
+	s.clear()
+	if err != nil {
+		return "", err
+	}
+	return p.result, nil
+}
+
+// readLines reads the secret, and its confirmation if enabled, from stdin.
+func (p *PasswordPrompt) readLines() (string, error) {
+	secret, err := readLine(stdin)
+	if err != nil {
+		return "", err
+	}
+	if p.confirm {
+		again, err := readLine(stdin)
+		if err != nil {
+			return "", err
+		}
+		if again != secret {
+			return "", ErrPasswordMismatch
+		}
+	}
+	return p.apply(secret)
+}
+
+// appendSecret appends runes to buf. If buf has to grow, the old buffer is wiped.
+func appendSecret(buf []rune, runes []rune) []rune {
+	if len(buf)+len(runes) <= cap(buf) {
+		return append(buf, runes...)
+	}
+	grown := make([]rune, len(buf), 2*cap(buf)+len(runes))
+	copy(grown, buf)
+	wipe(buf)
+	return append(grown, runes...)
+}
+
+// wipe overwrites the contents of a buffer that held a secret.
+func wipe(buf []rune) {
+	for i := range buf {
+		buf[i] = 0
+	}
+}
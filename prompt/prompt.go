@@ -1,6 +1,13 @@
 package prompt
 
-import "golang.org/x/exp/constraints"
+import (
+	"io"
+	"time"
+
+	"github.com/muesli/termenv"
+	"github.com/stelmanjones/termtools/theme"
+	"golang.org/x/exp/constraints"
+)
 
 // Value is a type constraint that represents any type that is ordered.
 type Value interface {
@@ -8,11 +15,36 @@ type Value interface {
 }
 
 // Base is the base struct for all prompts
-type Base[T Value] struct {
+type Base[T any] struct {
 	// PromptType
 	label    string
 	selector string
-	// theme *TermtoolsTheme
+	id       string
+	termio
+	km *KeyMap
+	th *theme.Theme
+}
+
+// SetTheme sets the theme of the prompt, replacing theme.Current.
+func (p *Base[T]) SetTheme(t *theme.Theme) *Base[T] {
+	p.th = t
+	return p
+}
+
+// styles returns the active theme of the prompt.
+func (p *Base[T]) styles() *theme.Theme {
+	if p.th == nil {
+		return theme.Current()
+	}
+	return p.th
+}
+
+// selectorMark returns the selector set for the prompt or else the one of its theme.
+func (p *Base[T]) selectorMark() string {
+	if p.selector == "" {
+		return p.styles().RenderSelector()
+	}
+	return p.selector
 }
 
 // SetSelector sets the selector for the prompt.
@@ -21,6 +53,57 @@ func (p *Base[T]) SetSelector(selector string) *Base[T] {
 	return p
 }
 
+// SetID sets the ID under which answer providers look up the answer of the prompt.
+func (p *Base[T]) SetID(id string) *Base[T] {
+	p.id = id
+	return p
+}
+
+// SetInput sets the source of key presses of the prompt. It replaces the keyboard,
+// and the prompt no longer falls back to reading stdin when stdin is not a terminal.
+func (p *Base[T]) SetInput(in Input) *Base[T] {
+	p.in = in
+	return p
+}
+
+// SetOutput sets where the prompt is rendered to. It replaces stdout.
+func (p *Base[T]) SetOutput(w io.Writer) *Base[T] {
+	p.out = termenv.NewOutput(w)
+	return p
+}
+
+// SetTimeout answers the prompt with its default once d has passed without
+// an answer. Prompts without a default, such as the password prompt, fail
+// with context.DeadlineExceeded instead.
+func (p *Base[T]) SetTimeout(d time.Duration) *Base[T] {
+	p.timeout = d
+	return p
+}
+
+// SetKeyMap sets the key bindings of the prompt, replacing DefaultKeyMap.
+func (p *Base[T]) SetKeyMap(km KeyMap) *Base[T] {
+	p.km = &km
+	return p
+}
+
+// keyMap returns the active key map of the prompt.
+func (p *Base[T]) keyMap() KeyMap {
+	if p.km == nil {
+		return DefaultKeyMap()
+	}
+	return *p.km
+}
+
+// ID returns the ID of the prompt.
+func (p *Base[T]) ID() string {
+	return p.id
+}
+
+// Label returns the label of the prompt.
+func (p *Base[T]) Label() string {
+	return p.label
+}
+
 // Ask is a convenience function that creates a new question prompt and runs it.
 func Ask(label string, defaultValue string, removeWhenDone bool) (string, error) {
 	p := NewQuestionPrompt(label)
@@ -34,7 +117,7 @@ func Ask(label string, defaultValue string, removeWhenDone bool) (string, error)
 }
 
 // Select is a convenience function that creates a new selection prompt and runs it.
-func Select[T Value](label string, choices []T, removeWhenDone bool) (*T, error) {
+func Select[T any](label string, choices []T, removeWhenDone bool) (*T, error) {
 	p := NewSelectionPrompt[T]()
 	p.SetLabel(label)
 	for _, choice := range choices {
@@ -47,6 +130,12 @@ func Select[T Value](label string, choices []T, removeWhenDone bool) (*T, error)
 	return p.Run()
 }
 
+// Choose is a convenience function that creates a new choice prompt with a
+// choice for every name, picked by its first unused letter, and runs it.
+func Choose(label string, names ...string) (string, error) {
+	return NewChoicePrompt(label, Choices(names...)...).Run()
+}
+
 // Confirm is a convenience function that creates a new confirmation prompt and runs it.
 func Confirm(label string) (bool, error) {
 	return NewConfirmationPrompt(label).Run()
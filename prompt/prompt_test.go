@@ -0,0 +1,259 @@
+package prompt_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stelmanjones/termtools/prompt"
+	"github.com/stelmanjones/termtools/prompt/prompttest"
+	"github.com/stelmanjones/termtools/theme"
+)
+
+func TestMain(m *testing.M) {
+	// golden files hold plain text, but the selector of a theme is a glyph
+	theme.Set(theme.Monochrome())
+	os.Exit(m.Run())
+}
+
+func TestSelectionPrompt(t *testing.T) {
+	in := prompttest.NewInput(prompttest.Down, prompttest.Down, prompttest.Enter)
+	rec := prompttest.NewRecorder()
+
+	p := prompt.NewSelectionPrompt("red", "green", "blue")
+	p.SetLabel("Pick a color")
+	p.SetInput(in).SetOutput(rec)
+	choice, err := p.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *choice != "blue" {
+		t.Errorf("got %q, want %q", *choice, "blue")
+	}
+	prompttest.Golden(t, "select_blue", rec.String())
+}
+
+func TestSelectionPromptCanceled(t *testing.T) {
+	in := prompttest.NewInput(prompttest.Down, prompttest.CtrlC)
+
+	p := prompt.NewSelectionPrompt("red", "green", "blue")
+	p.SetInput(in).SetOutput(prompttest.NewRecorder())
+	if _, err := p.Run(); err != prompt.ErrCanceledPrompt {
+		t.Fatalf("got %v, want %v", err, prompt.ErrCanceledPrompt)
+	}
+}
+
+func TestQuestionPrompt(t *testing.T) {
+	in := prompttest.NewInput(prompttest.Type("Gopherx")...).Press(prompttest.Backspace, prompttest.Enter)
+	rec := prompttest.NewRecorder()
+
+	p := prompt.NewQuestionPrompt("Name")
+	p.SetInput(in).SetOutput(rec)
+	answer, err := p.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer != "Gopher" {
+		t.Errorf("got %q, want %q", answer, "Gopher")
+	}
+	if in.Remaining() != 0 {
+		t.Errorf("%d key presses left", in.Remaining())
+	}
+	prompttest.Golden(t, "question_name", rec.String())
+}
+
+func TestQuestionPromptDefault(t *testing.T) {
+	in := prompttest.NewInput(prompttest.Enter)
+	rec := prompttest.NewRecorder()
+
+	p := prompt.NewQuestionPrompt("Name")
+	p.SetDefault("Gopher")
+	p.SetInput(in).SetOutput(rec)
+	answer, err := p.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer != "Gopher" {
+		t.Errorf("got %q, want %q", answer, "Gopher")
+	}
+	prompttest.Golden(t, "question_default", rec.String())
+}
+
+func TestQuestionPromptRecordsDefault(t *testing.T) {
+	h := prompt.NewMemoryHistory(0)
+	p := prompt.NewQuestionPrompt("Name").WithHistory(h)
+	p.SetDefault("Gopher")
+	p.SetInput(prompttest.NewInput(prompttest.Enter)).SetOutput(prompttest.NewRecorder())
+	if _, err := p.Run(); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ := h.Entries("Name")
+	if len(entries) != 1 || entries[0] != "Gopher" {
+		t.Errorf("got history %q, want the accepted default", entries)
+	}
+}
+
+func TestChoicesUniqueKeys(t *testing.T) {
+	var keys []rune
+	for _, c := range prompt.Choices("Save", "skip", "Stop", "stay") {
+		keys = append(keys, c.Key)
+	}
+	if string(keys) != "skta" {
+		t.Fatalf("got keys %q, want %q", string(keys), "skta")
+	}
+
+	in := prompttest.NewInput(prompttest.Rune('k'))
+	p := prompt.NewChoicePrompt("Next?", prompt.Choices("save", "skip")...)
+	p.SetInput(in).SetOutput(prompttest.NewRecorder())
+	answer, err := p.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer != "skip" {
+		t.Errorf("got %q, want %q", answer, "skip")
+	}
+}
+
+func TestConfirmPrompt(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		key  rune
+		want bool
+	}{
+		{"confirm_yes", 'y', true},
+		{"confirm_no", 'n', false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			in := prompttest.NewInput(prompttest.Rune(tt.key))
+			rec := prompttest.NewRecorder()
+
+			p := prompt.NewConfirmationPrompt("Continue?")
+			p.SetInput(in).SetOutput(rec)
+			got, err := p.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			prompttest.Golden(t, tt.name, rec.String())
+		})
+	}
+}
+
+func TestForm(t *testing.T) {
+	in := prompttest.NewInput(prompttest.Type("Gopher")...).
+		Press(prompttest.Enter, prompttest.Down, prompttest.Enter, prompttest.Rune('y'))
+	rec := prompttest.NewRecorder()
+
+	f := prompt.NewForm().
+		AddPage("Profile",
+			prompt.NewFormField("name", prompt.NewQuestionPrompt("Name")),
+			prompt.NewFormField("color", prompt.NewSelectionPrompt("red", "green", "blue")),
+		).
+		AddPage("Confirm",
+			prompt.NewFormField("ok", prompt.NewConfirmationPrompt("Save?")),
+		)
+	f.SetInput(in).SetOutput(rec)
+	answers, err := f.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Name  string `prompt:"name"`
+		Color string `prompt:"color"`
+		OK    bool   `prompt:"ok"`
+	}
+	if err := answers.Bind(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Gopher" || got.Color != "green" || !got.OK {
+		t.Errorf("got %+v", got)
+	}
+	if in.Remaining() != 0 {
+		t.Errorf("%d key presses left", in.Remaining())
+	}
+	prompttest.Golden(t, "form_profile", rec.String())
+}
+
+func TestEditorPromptWrapsAtDefaultWidth(t *testing.T) {
+	text := strings.Repeat("gopher ", 15)
+	in := prompttest.NewInput(prompttest.Type(text)...).Press(prompttest.CtrlD)
+	rec := prompttest.NewRecorder()
+
+	p := prompt.NewEditorPrompt("Notes")
+	p.SetInput(in).SetOutput(rec)
+	got, err := p.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != text {
+		t.Errorf("got %q, want %q", got, text)
+	}
+	// the output is not a terminal, so lines wrap at 80 columns whatever
+	// terminal runs the test
+	frames := rec.Frames()
+	prompttest.Golden(t, "editor_wrap", frames[len(frames)-2])
+}
+
+func TestFilePromptMultiEmptyAnswer(t *testing.T) {
+	prompt.SetAnswerProviders(prompt.Answers{"files": ""})
+	t.Cleanup(func() { prompt.SetAnswerProviders() })
+
+	p := prompt.NewFilePrompt("Files").WithMultiSelect()
+	p.SetID("files")
+	paths, err := p.RunMulti()
+	if !errors.Is(err, prompt.ErrInvalidAnswer) {
+		t.Fatalf("got %v, %v, want %v", paths, err, prompt.ErrInvalidAnswer)
+	}
+}
+
+func TestFilePromptRunAfterRunMulti(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() { prompt.SetAnswerProviders() })
+
+	p := prompt.NewFilePrompt("Files").WithDir(dir)
+	p.SetID("files")
+	prompt.SetAnswerProviders(prompt.Answers{"files": "a.txt" + string(filepath.ListSeparator) + "b.txt"})
+	paths, err := p.RunMulti()
+	if err != nil || len(paths) != 2 {
+		t.Fatalf("got %v, %v, want 2 paths", paths, err)
+	}
+
+	prompt.SetAnswerProviders(prompt.Answers{"files": "a.txt"})
+	path, err := p.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "a.txt"); path != want {
+		t.Errorf("got %q, want %q", path, want)
+	}
+}
+
+func TestFilePromptRunContextMulti(t *testing.T) {
+	p := prompt.NewFilePrompt("Files").WithMultiSelect()
+	p.SetInput(prompttest.NewInput()).SetOutput(prompttest.NewRecorder())
+	if _, err := p.RunContext(context.Background()); err != prompt.ErrMultiSelect {
+		t.Fatalf("got %v, want %v", err, prompt.ErrMultiSelect)
+	}
+}
+
+func TestFilePromptRunMultiContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := prompt.NewFilePrompt("Files").WithMultiSelect()
+	p.SetInput(prompttest.NewInput(prompttest.Down)).SetOutput(prompttest.NewRecorder())
+	if _, err := p.RunMultiContext(ctx); err != context.Canceled {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+}
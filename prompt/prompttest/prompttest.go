@@ -0,0 +1,217 @@
+// Package prompttest runs prompts without a terminal. An Input plays back a
+// script of key presses and a Recorder captures every rendered frame as plain
+// text, so prompt flows can be tested against golden files.
+//
+//	in := prompttest.NewInput(prompttest.Down, prompttest.Down, prompttest.Enter)
+//	rec := prompttest.NewRecorder()
+//
+//	p := prompt.NewSelectionPrompt("red", "green", "blue")
+//	p.SetInput(in).SetOutput(rec)
+//	choice, err := p.Run()
+//
+//	prompttest.Golden(t, "select_blue", rec.String())
+package prompttest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"atomicgo.dev/keyboard/keys"
+)
+
+// ErrScriptExhausted is returned by Input.Listen when the prompt asks for
+// more key presses than the script contains.
+var ErrScriptExhausted = errors.New("prompttest: no key presses left")
+
+// Key presses commonly used in scripts.
+var (
+	Up        = keys.Key{Code: keys.Up}
+	Down      = keys.Key{Code: keys.Down}
+	Left      = keys.Key{Code: keys.Left}
+	Right     = keys.Key{Code: keys.Right}
+	Enter     = keys.Key{Code: keys.Enter}
+	Tab       = keys.Key{Code: keys.Tab}
+	ShiftTab  = keys.Key{Code: keys.ShiftTab}
+	Esc       = keys.Key{Code: keys.Esc}
+	Backspace = keys.Key{Code: keys.Backspace}
+	Space     = keys.Key{Code: keys.Space, Runes: []rune{' '}}
+	CtrlC     = keys.Key{Code: keys.CtrlC}
+	CtrlD     = keys.Key{Code: keys.CtrlD}
+	CtrlR     = keys.Key{Code: keys.CtrlR}
+)
+
+// Rune returns the key press of the character r.
+func Rune(r rune) keys.Key {
+	return keys.Key{Code: keys.RuneKey, Runes: []rune{r}}
+}
+
+// Type returns a key press for every character of s.
+func Type(s string) []keys.Key {
+	ks := make([]keys.Key, 0, len(s))
+	for _, r := range s {
+		ks = append(ks, Rune(r))
+	}
+	return ks
+}
+
+// Input plays back a script of key presses. It implements prompt.Input.
+// Key presses left over when a prompt is done are passed to the next prompt
+// using the same Input.
+type Input struct {
+	mu     sync.Mutex
+	script []keys.Key
+}
+
+// NewInput creates a new Input that plays back script.
+func NewInput(script ...keys.Key) *Input {
+	return &Input{script: script}
+}
+
+// Press appends key presses to the script.
+func (in *Input) Press(ks ...keys.Key) *Input {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.script = append(in.script, ks...)
+	return in
+}
+
+// Type appends a key press for every character of s to the script.
+func (in *Input) Type(s string) *Input {
+	return in.Press(Type(s)...)
+}
+
+// Remaining returns the number of key presses that have not been played back.
+func (in *Input) Remaining() int {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return len(in.script)
+}
+
+// next removes the first key press from the script.
+func (in *Input) next() (keys.Key, bool) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if len(in.script) == 0 {
+		return keys.Key{}, false
+	}
+	key := in.script[0]
+	in.script = in.script[1:]
+	return key, true
+}
+
+// Listen passes the key presses of the script to onKey until it returns true
+// or an error. It returns ErrScriptExhausted if the script runs out first.
+func (in *Input) Listen(onKey func(key keys.Key) (stop bool, err error)) error {
+	for {
+		key, ok := in.next()
+		if !ok {
+			return ErrScriptExhausted
+		}
+		stop, err := onKey(key)
+		if err != nil {
+			return err
+		}
+		if stop {
+			return nil
+		}
+	}
+}
+
+// escapes matches ANSI escape sequences.
+var escapes = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(\x07|\x1b\\)`)
+
+// Plain removes all escape sequences, carriage returns and trailing spaces
+// from s.
+func Plain(s string) string {
+	s = strings.ReplaceAll(escapes.ReplaceAllString(s, ""), "\r", "")
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Recorder captures the frames rendered by prompts. Prompts write every frame
+// at once, so each write that leaves visible text is recorded as a frame.
+// It implements io.Writer.
+type Recorder struct {
+	mu     sync.Mutex
+	frames []string
+}
+
+// NewRecorder creates a new empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Write records p as a frame in plain text, unless it only moves the cursor
+// or clears the screen.
+func (r *Recorder) Write(p []byte) (int, error) {
+	frame := Plain(string(p))
+	if strings.TrimSpace(frame) == "" {
+		return len(p), nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.frames = append(r.frames, frame)
+	return len(p), nil
+}
+
+// Frames returns all recorded frames in order.
+func (r *Recorder) Frames() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.frames...)
+}
+
+// Last returns the last recorded frame or an empty string if there is none.
+func (r *Recorder) Last() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.frames) == 0 {
+		return ""
+	}
+	return r.frames[len(r.frames)-1]
+}
+
+// String returns all recorded frames, each preceded by a numbered header.
+func (r *Recorder) String() string {
+	var sb strings.Builder
+	for i, frame := range r.Frames() {
+		fmt.Fprintf(&sb, "--- frame %d ---\n%s\n", i+1, frame)
+	}
+	return sb.String()
+}
+
+// Golden compares got with the file testdata/<name>.golden and fails the test
+// if they differ. If the environment variable PROMPTTEST_UPDATE is set, the
+// file is written with got instead.
+func Golden(t testing.TB, name string, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+
+	if os.Getenv("PROMPTTEST_UPDATE") != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %v (set PROMPTTEST_UPDATE=1 to create it)", err)
+	}
+	if string(want) != got {
+		t.Errorf("%s does not match the golden file %s\n--- got ---\n%s\n--- want ---\n%s", name, path, got, want)
+	}
+}
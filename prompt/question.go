@@ -1,18 +1,37 @@
 package prompt
 
 import (
-	"fmt"
+	"context"
 	"strings"
+	"sync"
 
 	"atomicgo.dev/keyboard/keys"
-	"github.com/muesli/termenv"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // QuestionPrompt struct represents a prompt for a question.
 type QuestionPrompt struct {
 	Base[string]
+	validation[string]
 	defaultValue   string
 	removeWhenDone bool
+	input          []rune
+	result         string
+
+	history   History
+	past      []string // the answers in the history, oldest first
+	recall    int      // the index of the recalled answer, len(past) for the typed input
+	draft     []rune   // the typed input while an answer is recalled
+	searching bool
+	query     []rune
+	found     int // the index of the answer matching the query, -1 if none
+
+	completer   Completer
+	mu          sync.Mutex // guards the fields below, which are written by completions
+	suggestions []string
+	selected    int
+	generation  int
+	redraw      func()
 }
 
 // NewQuestionPrompt creates a new QuestionPrompt with the provided label.
@@ -30,6 +49,7 @@ func (p *QuestionPrompt) SetLabel(label string) {
 	p.label = label
 }
 
+// SetDefault sets the value returned when the user submits an empty answer.
 func (p *QuestionPrompt) SetDefault(v string) {
 	p.defaultValue = v
 }
@@ -46,45 +66,396 @@ func (p *QuestionPrompt) NoRemoveWhenDone() *QuestionPrompt {
 	return p
 }
 
-// render writes the label of the QuestionPrompt to the provided Output.
-func (p *QuestionPrompt) render(out *termenv.Output) {
-	if p.label != "" {
-		_, err := out.WriteString(p.label + " ")
-		if err != nil {
-			fmt.Println(err)
+// WithValidator adds a validator the answer has to pass before it is accepted.
+func (p *QuestionPrompt) WithValidator(v Validator[string]) *QuestionPrompt {
+	p.validators = append(p.validators, v)
+	return p
+}
+
+// WithTransform adds a transform that is applied to the answer before it is validated.
+func (p *QuestionPrompt) WithTransform(t Transform[string]) *QuestionPrompt {
+	p.transforms = append(p.transforms, t)
+	return p
+}
+
+// WithHistory records the answers of the prompt in h under its ID, or its
+// label if it has none. Up and down recall previous answers and ctrl+r
+// searches them. Without it the history set with SetHistory is used.
+func (p *QuestionPrompt) WithHistory(h History) *QuestionPrompt {
+	p.history = h
+	return p
+}
+
+// WithCompleter shows the suggestions of c in a dropdown beneath the input.
+// Tab accepts the highlighted suggestion and the arrow keys cycle through them.
+func (p *QuestionPrompt) WithCompleter(c Completer) *QuestionPrompt {
+	p.completer = c
+	return p
+}
+
+func (p *QuestionPrompt) reset() {
+	p.input = nil
+	p.result = ""
+	p.err = nil
+
+	p.past = nil
+	if h := p.store(); h != nil {
+		p.past, _ = h.Entries(p.historyKey())
+	}
+	p.recall = len(p.past)
+	p.draft = nil
+	p.searching = false
+
+	p.mu.Lock()
+	p.suggestions = nil
+	p.selected = 0
+	p.generation++
+	p.mu.Unlock()
+}
+
+// store returns the history of the prompt, if any.
+func (p *QuestionPrompt) store() History {
+	if p.history != nil {
+		return p.history
+	}
+	return history
+}
+
+// historyKey returns the key of the answers of the prompt in the history.
+func (p *QuestionPrompt) historyKey() string {
+	if p.id != "" {
+		return p.id
+	}
+	return p.label
+}
+
+// record adds answer to the history. A failing history doesn't fail the prompt.
+func (p *QuestionPrompt) record(answer string) {
+	if h := p.store(); h != nil && answer != "" {
+		_ = h.Add(p.historyKey(), answer)
+	}
+}
+
+// recallEntry replaces the input with the answer n entries newer in the
+// history. Moving past the newest answer restores the typed input.
+func (p *QuestionPrompt) recallEntry(n int) {
+	next := min(max(p.recall+n, 0), len(p.past))
+	if next == p.recall {
+		return
+	}
+	if p.recall == len(p.past) {
+		p.draft = p.input
+	}
+	p.recall = next
+	if next == len(p.past) {
+		p.input = p.draft
+	} else {
+		p.input = []rune(p.past[next])
+	}
+}
+
+// search finds the newest answer at or before index from that contains the query.
+func (p *QuestionPrompt) search(from int) {
+	p.found = -1
+	for i := min(from, len(p.past)-1); i >= 0; i-- {
+		if strings.Contains(p.past[i], string(p.query)) {
+			p.found = i
+			return
+		}
+	}
+}
+
+// updateSearch handles a key press while searching the history. Typed
+// characters extend the query, the search key finds an older match, enter
+// submits the match and cancel returns to the input.
+func (p *QuestionPrompt) updateSearch(km KeyMap, key keys.Key) (bool, error) {
+	switch {
+	case km.Search.command(key):
+		if prev := p.found; prev > 0 {
+			p.search(prev - 1)
+			if p.found < 0 {
+				p.found = prev // keep the oldest match
+			}
+		}
+	case km.Cancel.command(key):
+		p.searching = false
+	case km.Select.command(key):
+		p.searching = false
+		if p.found >= 0 {
+			p.input = []rune(p.past[p.found])
+		}
+		return p.submit()
+	case key.Code == keys.Backspace:
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
 		}
+		p.search(len(p.past) - 1)
+	case key.Code == keys.RuneKey || key.Code == keys.Space:
+		p.query = append(p.query, key.Runes...)
+		from := len(p.past) - 1
+		if p.found >= 0 {
+			from = p.found
+		}
+		p.search(from)
+	default:
+		// any other key ends the search, keeping the match as input
+		p.searching = false
+		if p.found >= 0 {
+			p.input = []rune(p.past[p.found])
+			p.recall = len(p.past)
+		}
+	}
+	return false, nil
+}
+
+// suggesting reports whether suggestions are shown.
+func (p *QuestionPrompt) suggesting() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.suggestions) > 0
+}
+
+func (p *QuestionPrompt) setRedraw(redraw func()) {
+	p.mu.Lock()
+	p.redraw = redraw
+	p.mu.Unlock()
+}
+
+// complete asks the completer for suggestions for the current input in the background.
+// Results for outdated input are discarded.
+func (p *QuestionPrompt) complete() {
+	if p.completer == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.generation++
+	generation := p.generation
+	p.mu.Unlock()
+
+	input := string(p.input)
+	go func() {
+		suggestions := p.completer(input)
+
+		p.mu.Lock()
+		if generation != p.generation {
+			p.mu.Unlock()
+			return
+		}
+		p.suggestions = suggestions
+		p.selected = 0
+		redraw := p.redraw
+		p.mu.Unlock()
+
+		if redraw != nil {
+			redraw()
+		}
+	}()
+}
+
+// cycle moves the highlighted suggestion by n, wrapping around at both ends.
+func (p *QuestionPrompt) cycle(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.suggestions) == 0 {
+		return
+	}
+	p.selected = (p.selected + n + len(p.suggestions)) % len(p.suggestions)
+}
+
+// accept replaces the input with the highlighted suggestion.
+// It reports false if there is no suggestion.
+func (p *QuestionPrompt) accept() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.suggestions) == 0 {
+		return false
+	}
+	p.input = []rune(p.suggestions[p.selected])
+	p.suggestions = nil
+	return true
+}
+
+// handles reports whether a form should pass key to the prompt: the complete
+// key accepts a suggestion while there are any, instead of moving to the next field.
+func (p *QuestionPrompt) handles(key keys.Key) bool {
+	return !p.searching && p.keyMap().Complete.command(key) && p.suggesting()
+}
+
+func (p *QuestionPrompt) update(key keys.Key) (bool, error) {
+	km := p.keyMap()
+	if p.searching {
+		return p.updateSearch(km, key)
+	}
+
+	switch {
+	case km.Cancel.command(key):
+		return false, ErrCanceledPrompt
+
+	case km.Select.command(key):
+		return p.submit()
+
+	case km.Complete.command(key):
+		if !p.accept() {
+			return false, nil
+		}
+
+	case km.Search.command(key) && len(p.past) > 0:
+		p.searching = true
+		p.query = nil
+		p.found = len(p.past) - 1
+		return false, nil
+
+	case km.Up.command(key) && p.suggesting():
+		p.cycle(-1)
+		return false, nil
+
+	case km.Down.command(key) && p.suggesting():
+		p.cycle(1)
+		return false, nil
+
+	case km.Up.command(key):
+		p.recallEntry(-1)
+
+	case km.Down.command(key):
+		p.recallEntry(1)
+
+	case key.Code == keys.Backspace:
+		if len(p.input) > 0 {
+			p.input = p.input[:len(p.input)-1]
+		}
+
+	default:
+		p.input = append(p.input, key.Runes...)
+	}
+	p.err = nil
+	p.complete()
+	return false, nil
+}
+
+// submit accepts the input, or the default if the input is empty.
+func (p *QuestionPrompt) submit() (bool, error) {
+	value := string(p.input)
+	if value == "" {
+		value = p.defaultValue
+	}
+	p.result, p.err = p.apply(value)
+	if p.err != nil {
+		return false, nil
+	}
+	p.record(value)
+	return true, nil
+}
+
+// line returns the label followed by value.
+func (p *QuestionPrompt) line(value string) string {
+	if p.label == "" {
+		return value
+	}
+	return p.label + " " + value
+}
+
+// view renders the label, the current input and the last validation error.
+// While searching the history the query and its match replace them.
+func (p *QuestionPrompt) view() (string, int, int) {
+	if p.searching {
+		return p.searchView()
+	}
+	line := p.line(string(p.input))
+	var sb strings.Builder
+	sb.WriteString(line)
+	p.renderSuggestions(&sb)
+	if p.err != nil {
+		sb.WriteString("\n" + p.styles().Error.Render(p.err.Error()))
+	}
+	return sb.String(), 0, lipgloss.Width(line)
+}
+
+// searchView renders the history search, with the cursor at the end of the query.
+func (p *QuestionPrompt) searchView() (string, int, int) {
+	t := p.styles()
+	prefix := "history search: "
+	match := ""
+	if p.found >= 0 {
+		match = p.past[p.found]
+	} else {
+		prefix = "failing history search: "
+	}
+	line := t.Muted.Render(prefix) + string(p.query)
+	km := p.keyMap()
+	controls := help(NewBinding("older", km.Search.Keys...), km.Select.commands(), NewBinding("back", km.Cancel.commands().Keys...))
+	return line + t.Muted.Render(" → ") + match + "\n" + t.Muted.Render(controls), 0, lipgloss.Width(line)
+}
+
+// renderSuggestions writes the dropdown of suggestions, scrolled so that the
+// highlighted suggestion is visible.
+func (p *QuestionPrompt) renderSuggestions(sb *strings.Builder) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t := p.styles()
+	start := max(0, p.selected-maxSuggestions+1)
+	end := min(len(p.suggestions), start+maxSuggestions)
+	for i := start; i < end; i++ {
+		if i == p.selected {
+			sb.WriteString("\n" + p.selectorMark() + t.Selected.Render(" "+p.suggestions[i]))
+		} else {
+			sb.WriteString("\n" + t.Unselected.Render("  "+p.suggestions[i]))
+		}
+	}
+}
+
+func (p *QuestionPrompt) summary() string {
+	return p.line(p.result)
+}
+
+func (p *QuestionPrompt) setAnswer(answer string) error {
+	if answer == "" {
+		answer = p.defaultValue
+	}
+	p.result, p.err = p.apply(answer)
+	return p.err
+}
+
+// useDefault answers the prompt with its default.
+func (p *QuestionPrompt) useDefault() error {
+	return p.setAnswer("")
+}
+
+func (p *QuestionPrompt) answer() any {
+	return p.result
 }
 
 // Run starts the QuestionPrompt and returns the user's input as a string.
+// Enter is refused until the input passes all validators.
+// A provided answer is returned without user interaction, as is a line read
+// from stdin when stdin is not a terminal.
 func (p *QuestionPrompt) Run() (string, error) {
-	out := termenv.DefaultOutput()
-	var input strings.Builder
-	p.render(out)
-	ch := make(chan keys.Key)
-	defer close(ch)
-	go ListenForInput(ch)
-
-outer:
-	for key := range ch {
-		switch key.Code {
-		case keys.CtrlC, keys.CtrlD, keys.Esc:
-			out.ClearLine()
-			return "", ErrCanceledPrompt
-
-		case keys.Enter:
-			break outer
-		case keys.RuneKey:
-			out.WriteString(key.String())
-			input.WriteString(key.String())
-		default:
-			out.WriteString(string(key.Runes))
-			input.WriteString(string(key.Runes))
-			continue
+	return p.RunContext(context.Background())
+}
 
+// RunContext is like Run, but gives up when ctx is done. The prompt is
+// removed, the terminal restored and ctx.Err() returned.
+func (p *QuestionPrompt) RunContext(ctx context.Context) (string, error) {
+	p.reset()
+	if ok, err := scripted(p.id, p, p.interactive()); ok {
+		if err != nil {
+			return "", err
 		}
+		return p.result, nil
+	}
+
+	s := newScreen(p.output())
+	if err := run(ctx, &p.termio, s, p); err != nil {
+		s.clear()
+		return "", err
+	}
+
+	if p.removeWhenDone {
+		s.clear()
+	} else {
+		s.render(p.summary(), -1, 0)
+		s.end()
 	}
-	out.ClearLine()
-	out.MoveCursor(0, 0)
-	return input.String(), nil
+	return p.result, nil
 }
@@ -0,0 +1,77 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// screen redraws a prompt in place. It remembers the size of the last frame
+// and where the cursor was left, so the next frame can replace it.
+type screen struct {
+	out   *termenv.Output
+	lines int // number of line breaks in the last frame
+	row   int // row of the cursor within the last frame
+}
+
+func newScreen(out *termenv.Output) *screen {
+	return &screen{out: out}
+}
+
+// home returns the sequence that moves the cursor to the first column of the
+// first line of the last frame.
+func (s *screen) home() string {
+	if s.row > 0 {
+		return fmt.Sprintf(termenv.CSI+termenv.CursorPreviousLineSeq, s.row)
+	}
+	return "\r"
+}
+
+// render replaces the last frame with view. If row is not negative the cursor
+// is placed at row and col of the new frame, otherwise it is left at the end.
+func (s *screen) render(view string, row, col int) {
+	var sb strings.Builder
+	sb.WriteString(s.home())
+	sb.WriteString(fmt.Sprintf(termenv.CSI+termenv.EraseDisplaySeq, 0))
+	sb.WriteString(view)
+
+	s.lines = strings.Count(view, "\n")
+	s.row = s.lines
+	if row >= 0 && row < s.lines {
+		sb.WriteString(fmt.Sprintf(termenv.CSI+termenv.CursorPreviousLineSeq, s.lines-row))
+		s.row = row
+	}
+	if row >= 0 {
+		sb.WriteString(fmt.Sprintf(termenv.CSI+termenv.CursorHorizontalSeq, col+1))
+	}
+
+	_, err := s.out.WriteString(sb.String())
+	if err != nil {
+		fmt.Println(err)
+	}
+}
+
+// clear removes the last frame from the terminal.
+func (s *screen) clear() {
+	_, err := s.out.WriteString(s.home() + fmt.Sprintf(termenv.CSI+termenv.EraseDisplaySeq, 0))
+	if err != nil {
+		fmt.Println(err)
+	}
+	s.lines, s.row = 0, 0
+}
+
+// end leaves the last frame on the terminal and moves the cursor to the
+// beginning of the line below it.
+func (s *screen) end() {
+	var sb strings.Builder
+	if s.lines > s.row {
+		sb.WriteString(fmt.Sprintf(termenv.CSI+termenv.CursorNextLineSeq, s.lines-s.row))
+	}
+	sb.WriteString("\n")
+	_, err := s.out.WriteString(sb.String())
+	if err != nil {
+		fmt.Println(err)
+	}
+	s.lines, s.row = 0, 0
+}
@@ -1,30 +1,50 @@
 package prompt
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"atomicgo.dev/keyboard/keys"
-	"github.com/muesli/termenv"
-	"github.com/stelmanjones/termtools/internal/theme"
 	"github.com/stelmanjones/termtools/usure"
 )
 
 // SelectionPrompt represents a prompt that allows the user to select from a list of choices.
-type SelectionPrompt[T Value] struct {
+// Choices can be of any type, WithRender controls how they are shown.
+type SelectionPrompt[T any] struct {
 	Base[T]             // The base prompt that the selection prompt inherits from.
+	validation[T]       // The transforms and validators applied to the selected choice.
 	Choices        []T  // The list of choices available for selection.
 	index          int  // The index of the currently selected choice.
 	removeWhenDone bool // Indicates whether the prompt should be removed from the screen when done.
+	hideControls   bool // Indicates whether the controls are hidden, as done inside a Form.
+	result         T    // The last accepted choice.
+	filtering      bool // Indicates whether typed characters are added to the filter.
+	filter         []rune
+	fuzzy          bool           // Indicates whether the filter matches characters in order rather than a substring.
+	height         int            // The number of choices shown at once, all if zero.
+	offset         int            // The number of matching choices scrolled past.
+	display        func(T) string // Renders a choice, fmt.Sprint if nil.
+	mark           func(T) string // Renders the mark in front of a choice, if not nil.
+	describe       func(T) string // Describes the highlighted choice, if not nil.
+	disabled       func(T) string // Returns why a choice can't be selected, if not nil.
+	groups         []group        // The section headers shown above the choices.
+}
+
+// group is a section header shown above the choices from index on.
+type group struct {
+	index int
+	title string
 }
 
 // NewSelectionPrompt creates a new instance of the SelectionPrompt.
 // It takes a variadic number of choices of type T.
-func NewSelectionPrompt[T Value](choices ...T) *SelectionPrompt[T] {
+func NewSelectionPrompt[T any](choices ...T) *SelectionPrompt[T] {
 	p := &SelectionPrompt[T]{
 		Base: Base[T]{
-			label:    "",
-			selector: theme.Selector,
+			label: "",
 		},
 		Choices: make([]T, 0),
 		index:   0,
@@ -46,11 +66,19 @@ func (p *SelectionPrompt[T]) AddChoices(choices ...T) {
 	p.Choices = append(p.Choices, choices...)
 }
 
+// AddGroup appends choices under a section header with the given title.
+// The header can't be selected.
+func (p *SelectionPrompt[T]) AddGroup(title string, choices ...T) {
+	p.groups = append(p.groups, group{index: len(p.Choices), title: title})
+	p.Choices = append(p.Choices, choices...)
+}
+
 // SetChoices sets the choices for the selection prompt.
 // It takes a variadic parameter of type T, representing the available choices.
-// This writes over any existing choices.
+// This writes over any existing choices and groups.
 func (p *SelectionPrompt[T]) SetChoices(choices ...T) {
 	p.Choices = choices
+	p.groups = nil
 }
 
 // SetLabel sets the label for the selection prompt.
@@ -63,93 +91,428 @@ func (p *SelectionPrompt[T]) RemoveWhenDone() {
 	p.removeWhenDone = true
 }
 
+// WithValidator adds a validator the selected choice has to pass before it is accepted.
+func (p *SelectionPrompt[T]) WithValidator(v Validator[T]) *SelectionPrompt[T] {
+	p.validators = append(p.validators, v)
+	return p
+}
+
+// WithTransform adds a transform that is applied to the selected choice before it is validated.
+func (p *SelectionPrompt[T]) WithTransform(t Transform[T]) *SelectionPrompt[T] {
+	p.transforms = append(p.transforms, t)
+	return p
+}
+
+// WithRender sets how choices are shown, instead of fmt.Sprint.
+// The filter and scripted answers match the rendered text.
+func (p *SelectionPrompt[T]) WithRender(render func(T) string) *SelectionPrompt[T] {
+	p.display = render
+	return p
+}
+
+// WithDescription shows the description of the highlighted choice beneath the choices.
+func (p *SelectionPrompt[T]) WithDescription(describe func(T) string) *SelectionPrompt[T] {
+	p.describe = describe
+	return p
+}
+
+// WithDisabled disables the choices for which reason returns a reason.
+// Disabled choices are shown with their reason but can't be selected.
+func (p *SelectionPrompt[T]) WithDisabled(reason func(T) string) *SelectionPrompt[T] {
+	p.disabled = reason
+	return p
+}
+
+// WithHeight shows at most n choices at once and scrolls through the rest.
+// PgUp and PgDown move by n choices. Zero shows all choices.
+func (p *SelectionPrompt[T]) WithHeight(n int) *SelectionPrompt[T] {
+	p.height = max(n, 0)
+	return p
+}
+
+// WithFuzzyFilter matches the filter against choices that contain its
+// characters in order, such as "slp" for "selection prompt", instead of
+// choices that contain it as a whole.
+func (p *SelectionPrompt[T]) WithFuzzyFilter() *SelectionPrompt[T] {
+	p.fuzzy = true
+	return p
+}
+
+// text renders the choice at index i.
+func (p *SelectionPrompt[T]) text(i int) string {
+	if p.display == nil {
+		return fmt.Sprint(p.Choices[i])
+	}
+	return p.display(p.Choices[i])
+}
+
+// matches reports whether the choice at index i passes the filter.
+func (p *SelectionPrompt[T]) matches(i int) bool {
+	if len(p.filter) == 0 {
+		return true
+	}
+	choice := strings.ToLower(p.text(i))
+	filter := strings.ToLower(string(p.filter))
+	if !p.fuzzy {
+		return strings.Contains(choice, filter)
+	}
+	for _, r := range filter {
+		j := strings.IndexRune(choice, r)
+		if j < 0 {
+			return false
+		}
+		choice = choice[j+len(string(r)):]
+	}
+	return true
+}
+
+// reason returns why the choice at index i is disabled, or "" if it isn't.
+func (p *SelectionPrompt[T]) reason(i int) string {
+	if p.disabled == nil {
+		return ""
+	}
+	return p.disabled(p.Choices[i])
+}
+
+// selectable reports whether the choice at index i passes the filter and isn't disabled.
+func (p *SelectionPrompt[T]) selectable(i int) bool {
+	return p.matches(i) && p.reason(i) == ""
+}
+
+// groupOf returns the index of the group of the choice at index i, or -1 if it has none.
+func (p *SelectionPrompt[T]) groupOf(i int) int {
+	g := -1
+	for j, group := range p.groups {
+		if group.index <= i {
+			g = j
+		}
+	}
+	return g
+}
+
+// move moves the index by step to the next selectable choice, wrapping around at both ends.
+func (p *SelectionPrompt[T]) move(step int) {
+	n := len(p.Choices)
+	for i, j := 1, p.index; i <= n; i++ {
+		j = (j + step + n) % n
+		if p.selectable(j) {
+			p.index = j
+			return
+		}
+	}
+}
+
+// page moves the index by a page of choices in the direction of step.
+func (p *SelectionPrompt[T]) page(step int) {
+	for i := 0; i < max(p.height, 1); i++ {
+		prev := p.index
+		p.move(step)
+		if (step > 0) != (p.index > prev) {
+			p.index = prev // stop at the ends instead of wrapping
+			return
+		}
+	}
+}
+
 func (p *SelectionPrompt[T]) increaseIndex() {
-	if p.index == len(p.Choices)-1 {
+	p.move(1)
+}
+
+func (p *SelectionPrompt[T]) decreaseIndex() {
+	p.move(-1)
+}
+
+// setFilter filters the choices by filter and highlights the first match
+// if the highlighted choice is filtered out.
+func (p *SelectionPrompt[T]) setFilter(filter []rune) {
+	p.filter = filter
+	if !p.selectable(p.index) {
+		p.move(1)
+	}
+}
+
+func (p *SelectionPrompt[T]) reset() {
+	p.result = *new(T)
+	p.err = nil
+	p.filtering = false
+	p.filter = nil
+	p.offset = 0
+	if p.index >= len(p.Choices) {
 		p.index = 0
-	} else {
-		p.index++
+	}
+	if len(p.Choices) > 0 && !p.selectable(p.index) {
+		p.move(1)
 	}
 }
 
-func (p *SelectionPrompt[T]) decreaseIndex() {
-	if p.index == 0 {
-		p.index = len(p.Choices) - 1
-	} else {
-		p.index--
+func (p *SelectionPrompt[T]) update(key keys.Key) (bool, error) {
+	km := p.keyMap()
+	if p.filtering {
+		return p.updateFilter(km, key)
+	}
+
+	switch {
+	case km.Select.Matches(key):
+		return p.submit()
+	case km.Cancel.Matches(key):
+		return false, ErrCanceledPrompt
+	case km.Down.Matches(key):
+		p.increaseIndex()
+	case km.Up.Matches(key):
+		p.decreaseIndex()
+	case p.height > 0 && km.NextPage.Matches(key):
+		p.page(1)
+	case p.height > 0 && km.PrevPage.Matches(key):
+		p.page(-1)
+	case km.Filter.Matches(key):
+		p.filtering = true
 	}
+	return false, nil
 }
 
-func (p *SelectionPrompt[T]) render(out *termenv.Output) {
-	out.ClearLines(len(p.Choices) + 4)
+// updateFilter handles a key press while filtering. Typed characters are
+// added to the filter, the filter key or esc clear it.
+func (p *SelectionPrompt[T]) updateFilter(km KeyMap, key keys.Key) (bool, error) {
+	switch {
+	case key.Code == keys.Esc || km.Filter.command(key):
+		p.filtering = false
+		p.setFilter(nil)
+	case km.Select.command(key):
+		return p.submit()
+	case km.Cancel.command(key):
+		return false, ErrCanceledPrompt
+	case km.Down.command(key):
+		p.increaseIndex()
+	case km.Up.command(key):
+		p.decreaseIndex()
+	case p.height > 0 && km.NextPage.command(key):
+		p.page(1)
+	case p.height > 0 && km.PrevPage.command(key):
+		p.page(-1)
+	case key.Code == keys.Backspace:
+		if len(p.filter) == 0 {
+			p.filtering = false
+			return false, nil
+		}
+		p.setFilter(p.filter[:len(p.filter)-1])
+	default:
+		p.setFilter(append(p.filter, key.Runes...))
+	}
+	return false, nil
+}
+
+// submit accepts the highlighted choice, unless the filter matches no
+// choice or all matching choices are disabled.
+func (p *SelectionPrompt[T]) submit() (bool, error) {
+	if !p.selectable(p.index) {
+		return false, nil
+	}
+	p.result, p.err = p.apply(p.Choices[p.index])
+	return p.err == nil, nil
+}
+
+func (p *SelectionPrompt[T]) view() (string, int, int) {
+	t := p.styles()
 	var sb strings.Builder
 	if p.label != "" {
 
-		_, err := sb.WriteString(theme.Title.Render(" " + p.label + " " + "\n\n"))
+		_, err := sb.WriteString(t.Title.Render(" "+p.label+" ") + "\n\n")
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+	sb.WriteString(p.listView())
+
+	if p.err != nil {
+		_, err := sb.WriteString("\n" + t.Error.Render(p.err.Error()) + "\n")
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	if !p.hideControls {
+		_, err := sb.WriteString("\n" + t.Muted.Render(" "+p.controls()) + "\n")
 		if err != nil {
 			fmt.Println(err)
 		}
 	}
-	for i, option := range p.Choices {
+	return strings.TrimSuffix(sb.String(), "\n"), -1, 0
+}
+
+// listView renders the filter and the choices in the viewport, one per line.
+func (p *SelectionPrompt[T]) listView() string {
+	t := p.styles()
+	var sb strings.Builder
+	if p.filtering || len(p.filter) > 0 {
+		sb.WriteString(t.Accent.Render("/"+string(p.filter)) + "\n")
+	}
+
+	visible := make([]int, 0, len(p.Choices))
+	cursor := 0
+	for i := range p.Choices {
+		if !p.matches(i) {
+			continue
+		}
 		if i == p.index {
-			_, err := sb.WriteString(p.selector + theme.SelectedOption.Render(fmt.Sprintf("  %v\n", option)))
-			if err != nil {
-				fmt.Println(err)
-			}
+			cursor = len(visible)
+		}
+		visible = append(visible, i)
+	}
+	if len(visible) == 0 {
+		sb.WriteString(t.Muted.Render("   no matches") + "\n")
+		return sb.String()
+	}
+
+	from, to := 0, len(visible)
+	if p.height > 0 && len(visible) > p.height {
+		// scroll just far enough to keep the highlighted choice in view
+		p.offset = min(max(p.offset, cursor-p.height+1), cursor, len(visible)-p.height)
+		from, to = p.offset, p.offset+p.height
+	}
+	if from > 0 {
+		sb.WriteString(t.Muted.Render(fmt.Sprintf("   ↑ %d more", from)) + "\n")
+	}
+	prev := -1
+	if from > 0 {
+		prev = p.groupOf(visible[from-1])
+	}
+	for _, i := range visible[from:to] {
+		if g := p.groupOf(i); g != prev {
+			sb.WriteString(" " + t.Title.Render(p.groups[g].title) + "\n")
+			prev = g
+		}
+		text := p.text(i)
+		if p.mark != nil {
+			text = p.mark(p.Choices[i]) + text
+		}
+		if reason := p.reason(i); reason != "" {
+			sb.WriteString(t.Muted.Render("   "+text+" ("+reason+")") + "\n")
+		} else if i == p.index {
+			sb.WriteString(p.selectorMark() + t.Selected.Render("  "+text) + "\n")
 		} else {
-			_, err := sb.WriteString(theme.NonSelectedOption.Render(fmt.Sprintf("   %v\n", option)))
-			if err != nil {
-				fmt.Println(err)
-			}
+			sb.WriteString(t.Unselected.Render("   "+text) + "\n")
+		}
+	}
+	if to < len(visible) {
+		sb.WriteString(t.Muted.Render(fmt.Sprintf("   ↓ %d more", len(visible)-to)) + "\n")
+	}
+	if p.describe != nil && p.selectable(p.index) {
+		if description := p.describe(p.Choices[p.index]); description != "" {
+			sb.WriteString("\n" + t.Muted.Render("   "+description) + "\n")
 		}
 	}
+	return sb.String()
+}
 
-	_, err := sb.WriteString(theme.SelectionPromptControls)
-	if err != nil {
-		fmt.Println(err)
+// controls describes the key bindings of the current mode.
+func (p *SelectionPrompt[T]) controls() string {
+	km := p.keyMap()
+	if p.filtering {
+		clear := km.Filter.commands()
+		clear.Keys = append([]string{"esc"}, clear.Keys...)
+		clear.Help = "clear filter"
+		cancel := km.Cancel.commands()
+		cancel.Keys = slices.DeleteFunc(cancel.Keys, func(k string) bool { return k == "esc" }) // esc clears the filter
+		return help(km.Up.commands(), km.Down.commands(), km.Select.commands(), clear, cancel)
 	}
-	out.WriteString(sb.String())
+	if p.height > 0 && p.height < len(p.Choices) {
+		return help(km.Up, km.Down, km.PrevPage, km.NextPage, km.Select, km.Filter, km.Cancel)
+	}
+	return help(km.Up, km.Down, km.Select, km.Filter, km.Cancel)
+}
+
+func (p *SelectionPrompt[T]) summary() string {
+	if p.display != nil {
+		return fmt.Sprintf("%s %s", p.label, p.display(p.result))
+	}
+	return fmt.Sprintf("%s %v", p.label, p.result)
+}
+
+// setAnswer selects the choice that is printed or rendered as answer, or the choice at the
+// 1-based position given by answer. An empty answer selects the highlighted choice.
+func (p *SelectionPrompt[T]) setAnswer(answer string) error {
+	index := -1
+	if answer == "" {
+		index = p.index
+	}
+	for i, choice := range p.Choices {
+		if index < 0 && (fmt.Sprint(choice) == answer || p.text(i) == answer) {
+			index = i
+		}
+	}
+	if n, err := strconv.Atoi(answer); index < 0 && err == nil && n >= 1 && n <= len(p.Choices) {
+		index = n - 1
+	}
+	if index < 0 {
+		return fmt.Errorf("%w: %q is not a choice", ErrInvalidAnswer, answer)
+	}
+	if reason := p.reason(index); reason != "" {
+		return fmt.Errorf("%w: %q is disabled: %s", ErrInvalidAnswer, answer, reason)
+	}
+
+	p.index = index
+	p.result, p.err = p.apply(p.Choices[index])
+	return p.err
+}
+
+func (p *SelectionPrompt[T]) answer() any {
+	return p.result
+}
+
+// useDefault selects the first choice that isn't disabled.
+func (p *SelectionPrompt[T]) useDefault() error {
+	p.filtering, p.filter, p.index = false, nil, 0
+	if !p.selectable(0) {
+		p.move(1)
+	}
+	return p.setAnswer("")
+}
+
+// embed hides the controls of the prompt when it is part of a Form.
+func (p *SelectionPrompt[T]) embed() {
+	p.hideControls = true
 }
 
 // Run executes the selection prompt and returns the selected choice and any error encountered.
-// If there are no options provided, it will panic.
+// Enter is refused until the selected choice passes all validators.
+// A provided answer is returned without user interaction, as is a line read
+// from stdin when stdin is not a terminal.
 func (p *SelectionPrompt[T]) Run() (*T, error) {
+	return p.RunContext(context.Background())
+}
+
+// RunContext is like Run, but gives up when ctx is done. The prompt is
+// removed, the terminal restored and ctx.Err() returned.
+func (p *SelectionPrompt[T]) RunContext(ctx context.Context) (*T, error) {
 	if usure.Equal(len(p.Choices), 0) {
 		return new(T), ErrNoChoices
 	}
-	out := termenv.DefaultOutput()
+	p.reset()
+	if p.reason(p.index) != "" {
+		return new(T), ErrNoChoices // all choices are disabled
+	}
+	if ok, err := scripted(p.id, p, p.interactive()); ok {
+		if err != nil {
+			return new(T), err
+		}
+		result := p.result
+		return &result, nil
+	}
+
+	out := p.output()
 	out.HideCursor()
 	defer out.ShowCursor()
-	p.render(out)
-	ch := make(chan keys.Key)
-	go ListenForInput(ch)
-
-outer:
-	for key := range ch {
-		switch key.Code {
-		case keys.RuneKey:
-			switch key.String() {
-			case "j", "J":
-				p.increaseIndex()
-
-			case "k", "K":
-				p.decreaseIndex()
-			}
-
-		case keys.Enter:
-			break outer
-		case keys.CtrlC, keys.CtrlD, keys.Esc:
-			return new(T), ErrCanceledPrompt
-		case keys.Down, keys.Tab:
-			p.increaseIndex()
-		case keys.Up, keys.ShiftTab:
-			p.decreaseIndex()
-		}
-		p.render(out)
-	}
-
-	close(ch)
+	s := newScreen(out)
+	if err := run(ctx, &p.termio, s, p); err != nil {
+		s.clear()
+		return new(T), err
+	}
+
 	if p.removeWhenDone {
-		out.ClearLines(len(p.Choices) + 4)
+		s.clear()
+	} else {
+		s.end()
 	}
-	return &p.Choices[p.index], nil
+	result := p.result
+	return &result, nil
 }
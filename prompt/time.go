@@ -0,0 +1,317 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"atomicgo.dev/keyboard/keys"
+)
+
+// TimePrompt is a prompt for a time of day. The left and right keys choose
+// between hours, minutes and seconds, up and down change the chosen field
+// and digits can be typed into it.
+type TimePrompt struct {
+	Base[string]
+	validation[time.Time]
+	defaultValue time.Time
+	format       string
+	seconds      bool
+	minuteStep   int
+	hideControls bool
+	fields       [3]int // hour, minute, second
+	field        int
+	typed        []rune
+	result       time.Time
+}
+
+// NewTimePrompt creates a new TimePrompt with the provided label.
+// It starts at the current time and formats times as 15:04.
+func NewTimePrompt(label string) *TimePrompt {
+	p := &TimePrompt{
+		Base: Base[string]{
+			label: label,
+		},
+		minuteStep: 1,
+	}
+	return p
+}
+
+// SetLabel sets the label of the TimePrompt.
+func (p *TimePrompt) SetLabel(label string) {
+	p.label = label
+}
+
+// SetDefault sets the time the prompt starts at instead of now.
+// Its date is also the date of the returned time.
+func (p *TimePrompt) SetDefault(v time.Time) {
+	p.defaultValue = v
+}
+
+// WithFormat sets the layout, as used by time.Format, of the time returned by
+// RunString, shown in the summary and expected from scripted answers.
+func (p *TimePrompt) WithFormat(layout string) *TimePrompt {
+	p.format = layout
+	return p
+}
+
+// WithSeconds adds a field for the seconds.
+func (p *TimePrompt) WithSeconds() *TimePrompt {
+	p.seconds = true
+	return p
+}
+
+// WithMinuteStep sets the amount of minutes up and down change the minutes by.
+func (p *TimePrompt) WithMinuteStep(step int) *TimePrompt {
+	p.minuteStep = max(step, 1)
+	return p
+}
+
+// WithValidator adds a validator the time has to pass before it is accepted.
+func (p *TimePrompt) WithValidator(v Validator[time.Time]) *TimePrompt {
+	p.validators = append(p.validators, v)
+	return p
+}
+
+// WithTransform adds a transform that is applied to the time before it is validated.
+func (p *TimePrompt) WithTransform(t Transform[time.Time]) *TimePrompt {
+	p.transforms = append(p.transforms, t)
+	return p
+}
+
+// layout returns the format of the prompt.
+func (p *TimePrompt) layout() string {
+	switch {
+	case p.format != "":
+		return p.format
+	case p.seconds:
+		return time.TimeOnly
+	default:
+		return "15:04"
+	}
+}
+
+// start returns the time the prompt starts at.
+func (p *TimePrompt) start() time.Time {
+	if p.defaultValue.IsZero() {
+		return time.Now()
+	}
+	return p.defaultValue
+}
+
+// count returns the number of fields.
+func (p *TimePrompt) count() int {
+	if p.seconds {
+		return 3
+	}
+	return 2
+}
+
+// limit returns the number of values of field i.
+func (p *TimePrompt) limit(i int) int {
+	if i == 0 {
+		return 24
+	}
+	return 60
+}
+
+// value returns the time of the fields on the date of the default.
+func (p *TimePrompt) value() time.Time {
+	y, m, d := p.start().Date()
+	return time.Date(y, m, d, p.fields[0], p.fields[1], p.fields[2], 0, time.Local)
+}
+
+func (p *TimePrompt) reset() {
+	t := p.start()
+	p.fields = [3]int{t.Hour(), t.Minute(), 0}
+	if p.seconds {
+		p.fields[2] = t.Second()
+	}
+	p.field = 0
+	p.typed = nil
+	p.result = time.Time{}
+	p.err = nil
+}
+
+// increment changes the chosen field by n steps, wrapping around.
+func (p *TimePrompt) increment(n int) {
+	step := 1
+	if p.field == 1 {
+		step = p.minuteStep
+	}
+	limit := p.limit(p.field)
+	v := p.fields[p.field]
+	switch {
+	case v%step == 0:
+		v += n * step
+	case n > 0:
+		v += step - v%step // move to the next multiple of step first
+	default:
+		v -= v % step
+	}
+	p.fields[p.field] = (v%limit + limit) % limit
+}
+
+// typeDigit types r into the chosen field. The next field is chosen once
+// two digits have been typed.
+func (p *TimePrompt) typeDigit(r rune) {
+	p.typed = append(p.typed, r)
+	v, _ := strconv.Atoi(string(p.typed))
+	if v >= p.limit(p.field) {
+		p.typed = []rune{r}
+		v = int(r - '0')
+	}
+	p.fields[p.field] = v
+	if len(p.typed) == 2 {
+		p.typed = nil
+		p.field = min(p.field+1, p.count()-1)
+	}
+}
+
+func (p *TimePrompt) update(key keys.Key) (bool, error) {
+	km := p.keyMap()
+	switch {
+	case km.Cancel.command(key):
+		return false, ErrCanceledPrompt
+	case km.Select.command(key):
+		return p.submit()
+	case km.Left.Matches(key), km.Prev.command(key):
+		p.field = max(p.field-1, 0)
+	case km.Right.Matches(key), km.Next.command(key):
+		p.field = min(p.field+1, p.count()-1)
+	case km.Up.Matches(key):
+		p.increment(1)
+	case km.Down.Matches(key):
+		p.increment(-1)
+	case key.Code == keys.RuneKey && len(key.Runes) == 1 && key.Runes[0] >= '0' && key.Runes[0] <= '9':
+		p.typeDigit(key.Runes[0])
+		p.err = nil
+		return false, nil
+	default:
+		return false, nil
+	}
+	p.typed = nil
+	p.err = nil
+	return false, nil
+}
+
+// submit accepts the time of the fields.
+func (p *TimePrompt) submit() (bool, error) {
+	p.result, p.err = p.apply(p.value())
+	return p.err == nil, nil
+}
+
+// view renders the label, the fields with the chosen one highlighted, the
+// last validation error and the controls.
+func (p *TimePrompt) view() (string, int, int) {
+	t := p.styles()
+	cursor := t.Selected
+	cursor.Reverse = true
+
+	parts := make([]string, p.count())
+	for i := range parts {
+		parts[i] = fmt.Sprintf("%02d", p.fields[i])
+		if i == p.field {
+			parts[i] = cursor.Render(parts[i])
+		}
+	}
+	view := strings.Join(parts, ":")
+	if p.label != "" {
+		view = p.label + " " + view
+	}
+	if p.err != nil {
+		view += "\n" + t.Error.Render(p.err.Error())
+	}
+	if !p.hideControls {
+		km := p.keyMap()
+		view += "\n" + t.Muted.Render(help(
+			NewBinding("field", append(km.Left.Keys, km.Right.Keys...)...),
+			NewBinding("change", append(km.Up.Keys, km.Down.Keys...)...),
+			km.Select,
+		))
+	}
+	return view, -1, 0
+}
+
+// embed hides the controls of the prompt when it is part of a Form.
+func (p *TimePrompt) embed() {
+	p.hideControls = true
+}
+
+func (p *TimePrompt) summary() string {
+	value := p.result.Format(p.layout())
+	if p.label == "" {
+		return value
+	}
+	return p.label + " " + value
+}
+
+// setAnswer accepts a time in the format of the prompt. An empty answer selects the default.
+func (p *TimePrompt) setAnswer(answer string) error {
+	start := p.start()
+	p.fields = [3]int{start.Hour(), start.Minute(), 0}
+	if p.seconds {
+		p.fields[2] = start.Second()
+	}
+	if answer = strings.TrimSpace(answer); answer != "" {
+		t, err := time.ParseInLocation(p.layout(), answer, time.Local)
+		if err != nil {
+			return fmt.Errorf("%w: %q is not a time like %s", ErrInvalidAnswer, answer, p.layout())
+		}
+		p.fields = [3]int{t.Hour(), t.Minute(), t.Second()}
+	}
+	p.result, p.err = p.apply(p.value())
+	return p.err
+}
+
+// useDefault answers the prompt with its default.
+func (p *TimePrompt) useDefault() error {
+	return p.setAnswer("")
+}
+
+func (p *TimePrompt) answer() any {
+	return p.result
+}
+
+// Run starts the TimePrompt and returns the picked time on the date of the default, today if unset.
+// A provided answer is returned without user interaction, as is a line read
+// from stdin when stdin is not a terminal.
+func (p *TimePrompt) Run() (time.Time, error) {
+	return p.RunContext(context.Background())
+}
+
+// RunContext is like Run, but gives up when ctx is done. The prompt is
+// removed, the terminal restored and ctx.Err() returned.
+func (p *TimePrompt) RunContext(ctx context.Context) (time.Time, error) {
+	p.reset()
+	if ok, err := scripted(p.id, p, p.interactive()); ok {
+		if err != nil {
+			return time.Time{}, err
+		}
+		return p.result, nil
+	}
+
+	out := p.output()
+	out.HideCursor()
+	defer out.ShowCursor()
+	s := newScreen(out)
+	err := run(ctx, &p.termio, s, p)
+	s.clear()
+	if err != nil {
+		return time.Time{}, err
+	}
+	s.render(p.summary(), -1, 0)
+	s.end()
+	return p.result, nil
+}
+
+// RunString runs the prompt and returns the picked time in the format of the prompt.
+func (p *TimePrompt) RunString() (string, error) {
+	t, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+	return t.Format(p.layout()), nil
+}
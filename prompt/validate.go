@@ -0,0 +1,118 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator checks a value entered into a prompt. A non-nil error rejects the
+// value and its message is shown beneath the prompt.
+type Validator[T any] func(T) error
+
+// Transform modifies a value entered into a prompt before it is validated.
+type Transform[T any] func(T) T
+
+// validation holds the transforms and validators of a prompt.
+type validation[T any] struct {
+	transforms []Transform[T]
+	validators []Validator[T]
+	err        error // the last validation error, rendered beneath the prompt
+}
+
+// apply runs all transforms and then all validators on value.
+// It returns the transformed value and the first validation error.
+func (v *validation[T]) apply(value T) (T, error) {
+	for _, t := range v.transforms {
+		value = t(value)
+	}
+	for _, validate := range v.validators {
+		if err := validate(value); err != nil {
+			return value, err
+		}
+	}
+	return value, nil
+}
+
+// Required returns a validator that rejects empty or whitespace only input.
+func Required() Validator[string] {
+	return func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return errors.New("a value is required")
+		}
+		return nil
+	}
+}
+
+// Regex returns a validator that rejects input not matching pattern.
+// It panics if pattern is not a valid regular expression.
+func Regex(pattern string) Validator[string] {
+	re := regexp.MustCompile(pattern)
+	return func(s string) error {
+		if !re.MatchString(s) {
+			return fmt.Errorf("must match %s", pattern)
+		}
+		return nil
+	}
+}
+
+// MinLength returns a validator that rejects input shorter than n characters.
+func MinLength(n int) Validator[string] {
+	return func(s string) error {
+		if utf8.RuneCountInString(s) < n {
+			return fmt.Errorf("must be at least %d characters long", n)
+		}
+		return nil
+	}
+}
+
+// MaxLength returns a validator that rejects input longer than n characters.
+func MaxLength(n int) Validator[string] {
+	return func(s string) error {
+		if utf8.RuneCountInString(s) > n {
+			return fmt.Errorf("must be at most %d characters long", n)
+		}
+		return nil
+	}
+}
+
+// IntRange returns a validator that rejects input that is not an integer
+// between min and max, inclusive.
+func IntRange(min, max int) Validator[string] {
+	return func(s string) error {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return errors.New("must be a whole number")
+		}
+		if n < min || n > max {
+			return fmt.Errorf("must be between %d and %d", min, max)
+		}
+		return nil
+	}
+}
+
+// Email returns a validator that rejects input that is not a plain email address.
+func Email() Validator[string] {
+	return func(s string) error {
+		addr, err := mail.ParseAddress(s)
+		if err != nil || addr.Address != s {
+			return errors.New("must be a valid email address")
+		}
+		return nil
+	}
+}
+
+// FileExists returns a validator that rejects paths that do not exist.
+func FileExists() Validator[string] {
+	return func(s string) error {
+		if _, err := os.Stat(s); err != nil {
+			return fmt.Errorf("%s does not exist", s)
+		}
+		return nil
+	}
+}
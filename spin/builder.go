@@ -4,9 +4,11 @@ import (
 	"io"
 	"os"
 	"sync"
+	"time"
 
 	"atomicgo.dev/keyboard/keys"
 	"github.com/gookit/color"
+	"github.com/stelmanjones/termtools/theme"
 )
 
 // SpinnerBuilder is a builder for the spinner.
@@ -21,6 +23,13 @@ type SpinnerBuilder struct {
 	cancelKeys []keys.KeyCode
 	variant    SpinnerVariant
 	color      color.Color
+	theme      *theme.Theme
+	glyphs     Glyphs
+	elapsed    bool
+	onCancel   func()
+	mode       Mode
+	heartbeat  time.Duration
+	groups     bool
 }
 
 // New returns a new spinner builder.
@@ -35,7 +44,8 @@ func New() *SpinnerBuilder {
 		suffix:     "",
 		cancelKeys: []keys.KeyCode{keys.CtrlC, keys.Escape},
 		variant:    Dots1,
-		color:      color.FgDefault,
+		glyphs:     DefaultGlyphs,
+		heartbeat:  DefaultHeartbeat,
 	}
 }
 
@@ -93,15 +103,60 @@ func (s *SpinnerBuilder) WithVariant(variant SpinnerVariant) *SpinnerBuilder {
 	return s
 }
 
-// WithColor sets the color of the spinner.
+// WithColor sets the color of the spinner. It takes precedence over the theme.
 func (s *SpinnerBuilder) WithColor(color color.Color) *SpinnerBuilder {
 	s.color = color
 	return s
 }
 
+// WithTheme sets the theme of the spinner, replacing theme.Current.
+func (s *SpinnerBuilder) WithTheme(t *theme.Theme) *SpinnerBuilder {
+	s.theme = t
+	return s
+}
+
+// WithGlyphs sets the glyphs printed by Success, Fail, Warn and Info.
+func (s *SpinnerBuilder) WithGlyphs(g Glyphs) *SpinnerBuilder {
+	s.glyphs = g
+	return s
+}
+
+// WithElapsed adds the time the spinner ran for to the line printed by
+// Success, Fail, Warn and Info.
+func (s *SpinnerBuilder) WithElapsed(show bool) *SpinnerBuilder {
+	s.elapsed = show
+	return s
+}
+
+// WithOnCancel sets the function called when the spinner is canceled with a cancel key.
+func (s *SpinnerBuilder) WithOnCancel(onCancel func()) *SpinnerBuilder {
+	s.onCancel = onCancel
+	return s
+}
+
+// WithMode sets how the spinner shows that it is running, see Mode.
+func (s *SpinnerBuilder) WithMode(mode Mode) *SpinnerBuilder {
+	s.mode = mode
+	return s
+}
+
+// WithHeartbeat sets how often the spinner prints that it is still running in
+// CI mode, DefaultHeartbeat by default. Zero disables heartbeat lines.
+func (s *SpinnerBuilder) WithHeartbeat(d time.Duration) *SpinnerBuilder {
+	s.heartbeat = d
+	return s
+}
+
+// WithGroupMarkers wraps the output of the spinner in CI mode in GitHub
+// Actions ::group:: and ::endgroup:: markers.
+func (s *SpinnerBuilder) WithGroupMarkers(enabled bool) *SpinnerBuilder {
+	s.groups = enabled
+	return s
+}
+
 // Build builds a new spinner with the given options.
 func (s *SpinnerBuilder) Build() *Spinner {
-	return &Spinner{
+	spinner := &Spinner{
 		Writer:     s.writer,
 		WriterFile: s.writerFile,
 		stopChan: make(chan struct{}),
@@ -115,5 +170,15 @@ func (s *SpinnerBuilder) Build() *Spinner {
 		CancelKeys: s.cancelKeys,
 		variant:    s.variant,
 		Color:      s.color,
+		theme:      s.theme,
+		glyphs:     s.glyphs,
+		elapsed:    s.elapsed,
+		done:       make(chan struct{}),
+		OnCancel:   s.onCancel,
+		mode:       s.mode,
+		heartbeat:  s.heartbeat,
+		groups:     s.groups,
 	}
+	spinner.lines = &lineWriter{s: spinner}
+	return spinner
 }
@@ -0,0 +1,111 @@
+package spin
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Mode selects how a spinner shows that it is running.
+type Mode int
+
+const (
+	// ModeAuto animates the spinner on a terminal and uses ModeCI when the
+	// writer is not a terminal or the CI environment variable is set.
+	ModeAuto Mode = iota
+	// ModeTerminal always animates the spinner.
+	ModeTerminal
+	// ModeCI prints a line when the spinner starts, a heartbeat line with the
+	// elapsed time every heartbeat interval and a final status line, which
+	// suits logs that can't be redrawn.
+	ModeCI
+)
+
+// DefaultHeartbeat is how often a spinner in CI mode prints that it is still running.
+const DefaultHeartbeat = 30 * time.Second
+
+// SetMode sets how the spinner shows that it is running. It takes effect on the next Start.
+func (s *Spinner) SetMode(m Mode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mode = m
+}
+
+// SetHeartbeat sets how often the spinner prints that it is still running in
+// CI mode. Zero or less disables heartbeat lines.
+func (s *Spinner) SetHeartbeat(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.heartbeat = d
+}
+
+// SetGroupMarkers sets whether the output of the spinner in CI mode is
+// wrapped in GitHub Actions ::group:: and ::endgroup:: markers, so the log
+// of the step can be folded. The final status line follows the group.
+func (s *Spinner) SetGroupMarkers(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groups = enabled
+}
+
+// animate reports whether the spinner is animated rather than run in CI mode.
+func (s *Spinner) animate() bool {
+	switch s.mode {
+	case ModeTerminal:
+		return true
+	case ModeCI:
+		return false
+	}
+	return isTerminal(s) && os.Getenv("CI") == ""
+}
+
+// label returns the text of the spinner without its frame.
+func (s *Spinner) label() string {
+	parts := make([]string, 0, 2)
+	for _, p := range []string{s.Prefix, s.Suffix} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// startCI prints the start line of the spinner in CI mode and starts its
+// heartbeat. It is called with mu held.
+func (s *Spinner) startCI() {
+	if s.groups {
+		fmt.Fprintf(s.Writer, "::group::%s\n", s.label())
+	} else {
+		fmt.Fprintf(s.Writer, "%s...\n", s.label())
+	}
+	if s.heartbeat <= 0 {
+		return
+	}
+	s.stopped = make(chan struct{})
+	go s.beat(s.stopChan, s.stopped, s.heartbeat)
+}
+
+// beat prints a heartbeat line every interval until stop is closed, and closes stopped when it returns.
+func (s *Spinner) beat(stop, stopped chan struct{}, interval time.Duration) {
+	defer close(stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			fmt.Fprintf(s.Writer, "%s... (%s)\n", s.label(), formatElapsed(time.Since(s.started)))
+			s.mu.Unlock()
+		}
+	}
+}
+
+// endCI closes the group of the spinner in CI mode. It is called with mu held.
+func (s *Spinner) endCI() {
+	if s.groups {
+		fmt.Fprintln(s.Writer, "::endgroup::")
+	}
+}
@@ -0,0 +1,12 @@
+package spin
+
+import (
+	"errors"
+)
+
+var (
+	// ErrCanceled is the cause of the context of a spinner whose cancel key was pressed.
+	ErrCanceled = errors.New("spinner canceled")
+	// ErrInvalidVariant is returned when a variant file describes a variant that can't be played.
+	ErrInvalidVariant = errors.New("invalid spinner variant")
+)
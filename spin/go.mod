@@ -5,17 +5,28 @@ go 1.23.0
 require (
 	atomicgo.dev/cursor v0.2.0
 	atomicgo.dev/keyboard v0.2.9
+	github.com/charmbracelet/log v0.4.0
 	github.com/gookit/color v1.5.4
+	github.com/muesli/termenv v0.15.2
 	github.com/stelmanjones/termtools/text v0.0.0-20240810205715-64ac7a9ad647
-	golang.org/x/term v0.19.0
+	github.com/stelmanjones/termtools/theme v0.0.0-20261019160604-c8b5e8030ca7
+	github.com/stelmanjones/termtools/tty v0.0.0-20261019160304-8494147e8eb0
+	golang.org/x/term v0.25.0
 )
 
 require (
+	github.com/BurntSushi/toml v1.4.0 // indirect
+	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
+	github.com/charmbracelet/lipgloss v0.10.0 // indirect
 	github.com/containerd/console v1.0.4 // indirect
+	github.com/go-logfmt/logfmt v0.6.0 // indirect
+	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/muesli/reflow v0.3.0 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 	github.com/stretchr/testify v1.9.0 // indirect
 	github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
 	golang.org/x/exp v0.0.0-20240416160154-fe59bbe5cc7f // indirect
-	golang.org/x/sys v0.19.0 // indirect
+	golang.org/x/sys v0.26.0 // indirect
 )
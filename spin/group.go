@@ -0,0 +1,328 @@
+package spin
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/stelmanjones/termtools/text"
+	"github.com/stelmanjones/termtools/theme"
+	"golang.org/x/term"
+)
+
+// TaskState is the state of a task in a Group.
+type TaskState int
+
+const (
+	// TaskRunning is the state of a task that is not finished yet.
+	TaskRunning TaskState = iota
+	// TaskDone is the state of a task that finished successfully.
+	TaskDone
+	// TaskFailed is the state of a task that failed.
+	TaskFailed
+)
+
+// Task is a line of a Group with its own spinner, text and timing.
+// Its methods are safe to call from any goroutine.
+type Task struct {
+	g       *Group
+	text    string
+	state   TaskState
+	err     error
+	started time.Time
+	ended   time.Time
+}
+
+// SetText replaces the text of the task.
+func (t *Task) SetText(text string) {
+	t.g.mu.Lock()
+	defer t.g.mu.Unlock()
+	t.text = text
+}
+
+// Text returns the text of the task.
+func (t *Task) Text() string {
+	t.g.mu.Lock()
+	defer t.g.mu.Unlock()
+	return t.text
+}
+
+// State returns the state of the task.
+func (t *Task) State() TaskState {
+	t.g.mu.Lock()
+	defer t.g.mu.Unlock()
+	return t.state
+}
+
+// Elapsed returns how long the task has been running, or ran for if it is finished.
+func (t *Task) Elapsed() time.Duration {
+	t.g.mu.Lock()
+	defer t.g.mu.Unlock()
+	return t.elapsed(time.Now())
+}
+
+func (t *Task) elapsed(now time.Time) time.Duration {
+	if t.state != TaskRunning {
+		return t.ended.Sub(t.started)
+	}
+	return now.Sub(t.started)
+}
+
+// Done marks the task as done. It is collapsed into the summary line of the group.
+func (t *Task) Done() {
+	t.g.finish(t, TaskDone, nil)
+}
+
+// Fail marks the task as failed. It stays visible together with err.
+func (t *Task) Fail(err error) {
+	t.g.finish(t, TaskFailed, err)
+}
+
+// Remove removes the task from the group without counting it in the summary.
+func (t *Task) Remove() {
+	t.g.mu.Lock()
+	defer t.g.mu.Unlock()
+	t.g.tasks = slices.DeleteFunc(t.g.tasks, func(o *Task) bool { return o == t })
+}
+
+// Group renders several tasks with spinners on separate lines. All tasks are
+// redrawn by a single render loop, and tasks can be added and finished from
+// many goroutines. Finished tasks are collapsed into a summary line.
+type Group struct {
+	mu       sync.Mutex
+	writer   io.Writer
+	variant  SpinnerVariant
+	color    color.Color
+	theme    *theme.Theme
+	glyphs   Glyphs
+	tasks    []*Task // running and failed tasks, in the order they were added
+	done     int     // tasks collapsed into the summary line
+	lines    int     // lines drawn by the last frame
+	terminal bool
+	running  bool
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// NewGroup returns a new Group that renders to stdout.
+func NewGroup() *Group {
+	return &Group{
+		writer:  os.Stdout,
+		variant: Dots1,
+		glyphs:  DefaultGlyphs,
+	}
+}
+
+// WithWriter sets the writer of the group. Unless it is a terminal, or if the
+// CI environment variable is set, tasks are printed once when they finish
+// instead of being animated.
+func (g *Group) WithWriter(w io.Writer) *Group {
+	g.writer = w
+	return g
+}
+
+// WithVariant sets the variant of the spinners of the group.
+func (g *Group) WithVariant(variant SpinnerVariant) *Group {
+	g.variant = variant
+	return g
+}
+
+// WithColor sets the color of the spinners. It takes precedence over the theme.
+func (g *Group) WithColor(c color.Color) *Group {
+	g.color = c
+	return g
+}
+
+// WithTheme sets the theme of the group, replacing theme.Current.
+func (g *Group) WithTheme(t *theme.Theme) *Group {
+	g.theme = t
+	return g
+}
+
+// WithGlyphs sets the glyphs of finished tasks and the summary line.
+func (g *Group) WithGlyphs(glyphs Glyphs) *Group {
+	g.glyphs = glyphs
+	return g
+}
+
+// styles returns the active theme of the group.
+func (g *Group) styles() *theme.Theme {
+	if g.theme == nil {
+		return theme.Current()
+	}
+	return g.theme
+}
+
+// Add adds a running task with the given text to the group.
+func (g *Group) Add(text string) *Task {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	t := &Task{g: g, text: text, started: time.Now()}
+	g.tasks = append(g.tasks, t)
+	return t
+}
+
+// finish ends a running task with state. Outside a terminal the task is printed right away.
+func (g *Group) finish(t *Task, state TaskState, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if t.state != TaskRunning {
+		return
+	}
+	t.state, t.err, t.ended = state, err, time.Now()
+	if !g.terminal || !g.running {
+		fmt.Fprintln(g.writer, g.line(t, t.ended))
+	}
+	if state == TaskDone || !g.terminal {
+		g.tasks = slices.DeleteFunc(g.tasks, func(o *Task) bool { return o == t })
+		g.done++
+	}
+}
+
+// line renders a task.
+func (g *Group) line(t *Task, now time.Time) string {
+	th := g.styles()
+	elapsed := th.Muted.Render(fmt.Sprintf(" (%s)", formatElapsed(t.elapsed(now))))
+	switch t.state {
+	case TaskDone:
+		return statusSuccess.render(g.glyphs, th) + " " + t.text + elapsed
+	case TaskFailed:
+		text := t.text
+		if t.err != nil {
+			text += ": " + t.err.Error()
+		}
+		return statusFail.render(g.glyphs, th) + " " + text + elapsed
+	}
+
+	frame := g.variant.FrameAt(now.Sub(t.started))
+	switch {
+	case strings.Contains(frame, "\x1b["):
+		// the frame carries its own colors
+	case g.color != 0:
+		frame = g.color.Sprintf("%s", frame)
+	default:
+		frame = th.Spinner.Render(frame)
+	}
+	return frame + " " + t.text + elapsed
+}
+
+// summary renders the number of finished tasks, or "" if there are none.
+func (g *Group) summary() string {
+	if g.done == 0 {
+		return ""
+	}
+	th := g.styles()
+	word := "tasks"
+	if g.done == 1 {
+		word = "task"
+	}
+	return statusSuccess.render(g.glyphs, th) + th.Muted.Render(fmt.Sprintf(" %d %s done", g.done, word))
+}
+
+// width returns the width of the terminal of the group, or math.MaxInt if it
+// is unknown.
+func (g *Group) width() int {
+	if f, ok := g.writer.(*os.File); ok {
+		if w, _, err := term.GetSize(int(f.Fd())); err == nil && w > 0 {
+			return w
+		}
+	}
+	return math.MaxInt
+}
+
+// rows returns the number of rows s takes on a terminal width cells wide.
+func rows(s string, width int) int {
+	n := 0
+	for _, line := range strings.Split(s, "\n") {
+		n += 1 + max(text.VisibleLength(line)-1, 0)/width
+	}
+	return n
+}
+
+// draw replaces the last frame with the current one, wrapping lines at width.
+// It is called with mu held.
+func (g *Group) draw(now time.Time, width int) {
+	var sb strings.Builder
+	if g.lines > 0 {
+		fmt.Fprintf(&sb, "\033[%dF", g.lines)
+	}
+	sb.WriteString("\r\033[J")
+
+	g.lines = 0
+	if summary := g.summary(); summary != "" {
+		sb.WriteString(summary + "\n")
+		g.lines += rows(summary, width)
+	}
+	for _, t := range g.tasks {
+		line := g.line(t, now)
+		sb.WriteString(line + "\n")
+		g.lines += rows(line, width)
+	}
+	fmt.Fprint(g.writer, sb.String())
+}
+
+// Start starts the render loop of the group.
+func (g *Group) Start() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.running {
+		return
+	}
+	f, ok := g.writer.(*os.File)
+	g.terminal = ok && term.IsTerminal(int(f.Fd())) && os.Getenv("CI") == ""
+	g.running = true
+	if !g.terminal {
+		return
+	}
+
+	g.stop, g.stopped = make(chan struct{}), make(chan struct{})
+	fmt.Fprint(g.writer, "\033[?25l")
+	go g.loop(g.stop, g.stopped)
+}
+
+// loop redraws the group as often as the frames of its variant change until stop is closed.
+func (g *Group) loop(stop, stopped chan struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(g.variant.tick())
+	defer ticker.Stop()
+	for {
+		g.mu.Lock()
+		g.draw(time.Now(), g.width())
+		g.mu.Unlock()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops the render loop and leaves the last frame on the screen.
+// It is safe to call more than once.
+func (g *Group) Stop() {
+	g.mu.Lock()
+	if !g.running {
+		g.mu.Unlock()
+		return
+	}
+	g.running = false
+	stop, stopped := g.stop, g.stopped
+	g.mu.Unlock()
+	if !g.terminal {
+		return
+	}
+
+	close(stop)
+	<-stopped
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.draw(time.Now(), g.width())
+	g.lines = 0
+	fmt.Fprint(g.writer, "\033[?25h")
+}
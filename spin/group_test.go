@@ -0,0 +1,68 @@
+package spin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGroupDrawWrapped(t *testing.T) {
+	var out bytes.Buffer
+	g := NewGroup().WithWriter(&out)
+	g.Add(strings.Repeat("x", 25))
+	g.Add("short")
+
+	// the spinner, a space, the text and the elapsed time are wider than 10
+	// cells, so the first task wraps onto 4 rows and the second onto 2
+	now := time.Now()
+	g.draw(now, 10)
+	if g.lines != 6 {
+		t.Fatalf("drew %d rows, want 6", g.lines)
+	}
+	out.Reset()
+	g.draw(now, 10)
+	if !strings.HasPrefix(out.String(), "\033[6F") {
+		t.Errorf("frame starts with %q, want to move up 6 rows", out.String()[:min(out.Len(), 8)])
+	}
+}
+
+func TestGroupConcurrent(t *testing.T) {
+	var out bytes.Buffer
+	g := NewGroup().WithWriter(&out)
+	// render as on a terminal, so drawing races with the changes to the tasks
+	g.terminal, g.running = true, true
+	g.stop, g.stopped = make(chan struct{}), make(chan struct{})
+	go g.loop(g.stop, g.stopped)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				task := g.Add(fmt.Sprintf("task %d.%d", i, n))
+				task.SetText(task.Text() + " running")
+				switch n % 3 {
+				case 0:
+					task.Done()
+				case 1:
+					task.Fail(errors.New("failed"))
+				default:
+					task.Remove()
+				}
+				_ = task.State()
+			}
+		}()
+	}
+	wg.Wait()
+	g.Stop()
+
+	// 17 of the 50 tasks of every goroutine are done and 17 failed
+	if g.done != 8*17 || len(g.tasks) != 8*17 {
+		t.Errorf("got %d done and %d failed tasks, want %d of each", g.done, len(g.tasks), 8*17)
+	}
+}
@@ -0,0 +1,95 @@
+package spin
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/muesli/termenv"
+)
+
+// lineWriter writes whole lines above a running spinner.
+type lineWriter struct {
+	s   *Spinner
+	buf []byte // the unfinished last line, kept until its newline is written
+}
+
+// Write erases the spinner, writes the finished lines of p and redraws the
+// spinner below them. Unless the spinner is drawn on a terminal, p is passed
+// straight through.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	s := w.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running || !s.animated {
+		if err := w.flush(); err != nil {
+			return 0, err
+		}
+		return s.Writer.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+	i := bytes.LastIndexByte(w.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	last := s.lastOut
+	s.erase()
+	_, err := s.Writer.Write(w.buf[:i+1])
+	w.buf = append(w.buf[:0], w.buf[i+1:]...)
+	s.lastOut = last
+	fmt.Fprint(s.Writer, "\r"+last)
+	return len(p), err
+}
+
+// flush writes the unfinished line, if any. It is called with the mutex of the spinner held.
+func (w *lineWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.s.Writer.Write(w.buf)
+	w.buf = w.buf[:0]
+	return err
+}
+
+// end writes the unfinished line, if any, followed by a newline, so the final
+// line of the spinner starts on a line of its own.
+func (w *lineWriter) end() error {
+	if len(w.buf) > 0 {
+		w.buf = append(w.buf, '\n')
+	}
+	return w.flush()
+}
+
+// LineWriter returns a writer that prints lines above the spinner while it
+// runs, so output of the program doesn't corrupt the spinner line. Lines are
+// written once their newline is; an unfinished line is written when the
+// spinner stops. Unless the spinner is drawn on a terminal, everything is
+// passed straight through to its writer.
+//
+// It can be set as the output of an existing logger:
+//
+//	logger.SetOutput(s.LineWriter())
+func (s *Spinner) LineWriter() io.Writer {
+	return s.lines
+}
+
+// Printf prints a line above the spinner. A newline is added unless format ends with one.
+func (s *Spinner) Printf(format string, a ...any) {
+	line := fmt.Sprintf(format, a...)
+	if !strings.HasSuffix(line, "\n") {
+		line += "\n"
+	}
+	_, _ = s.lines.Write([]byte(line))
+}
+
+// Logger returns a charmbracelet/log logger with the given options that
+// prints above the spinner. It uses colors if the writer file of the spinner
+// is a terminal that supports them.
+func (s *Spinner) Logger(opts log.Options) *log.Logger {
+	l := log.NewWithOptions(s.lines, opts)
+	l.SetColorProfile(termenv.NewOutput(s.WriterFile).ColorProfile())
+	return l
+}
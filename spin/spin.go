@@ -1,24 +1,27 @@
 package spin
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"os"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 	"unicode/utf8"
 
-	"atomicgo.dev/cursor"
-	"atomicgo.dev/keyboard"
 	"atomicgo.dev/keyboard/keys"
 
 	"golang.org/x/term"
 
 	"github.com/gookit/color"
 	"github.com/stelmanjones/termtools/text"
+	"github.com/stelmanjones/termtools/theme"
+	"github.com/stelmanjones/termtools/tty/keypress"
 )
 
 var (
@@ -41,14 +44,47 @@ type Spinner struct {
 	CancelKeys []keys.KeyCode
 	variant    SpinnerVariant
 	running    bool
-	Color      color.Color
+	stopped    chan struct{} // closed when the render loop returns, nil if there is none
+	listener   *listener     // nil if cancel keys are not read
+	ctx        context.Context
+	cancel     context.CancelCauseFunc
+	done       chan struct{}
+	lines      *lineWriter
+	mode       Mode
+	animated   bool // whether the current run is animated rather than in CI mode
+	heartbeat  time.Duration
+	groups     bool
+	OnCancel   func() // called when the spinner is canceled with a cancel key
+	started    time.Time
+	glyphs     Glyphs
+	elapsed    bool        // add the elapsed time to the final line
+	Color      color.Color // overrides the Spinner style of the theme unless zero
+	theme      *theme.Theme
 }
 
-// SetColor sets the color of the spinner.
+// SetColor sets the color of the spinner. It takes precedence over the theme.
 func (s *Spinner) SetColor(c color.Color) {
 	s.Color = c
 }
 
+// SetTheme sets the theme of the spinner, replacing theme.Current.
+// The frames are rendered in its Spinner style.
+func (s *Spinner) SetTheme(t *theme.Theme) {
+	s.theme = t
+}
+
+// frame styles a frame of the spinner with its color, or with its theme if no
+// color is set. Frames that carry their own colors are kept as they are.
+func (s *Spinner) frame(c string) string {
+	if strings.Contains(c, "\x1b[") {
+		return c
+	}
+	if s.Color != 0 {
+		return s.Color.Sprintf("%s", c)
+	}
+	return s.styles().Spinner.Render(c)
+}
+
 // SetPrefix returns an  function that sets the Prefix field of a Spinner.
 func (s *Spinner) SetPrefix(p string) {
 	s.Prefix = p
@@ -89,64 +125,185 @@ func (s *Spinner) SetWriterFile(f *os.File) {
 	s.mu.Unlock()
 }
 
-// Start starts the spinner.
+// Start starts the spinner. It does nothing if the spinner is already running.
+// Unless the writer is a terminal the spinner runs in CI mode, see Mode.
 func (s *Spinner) Start() {
-	s.running = true
+	s.StartContext(context.Background())
+}
+
+// StartContext starts the spinner and returns a context derived from ctx for
+// the work the spinner waits for. Pressing a cancel key cancels that context
+// with ErrCanceled, stops the spinner and calls OnCancel, leaving it to the
+// application to decide how to exit. The spinner also stops when ctx is done,
+// and the returned context is canceled when the spinner stops.
+// If the spinner is already running, its current context is returned.
+func (s *Spinner) StartContext(ctx context.Context) context.Context {
 	s.mu.Lock()
-	fmt.Fprint(s.Writer, "\033[?25l")
-	if !isTerminal(s) {
-		s.ShowCursor()
-		cursor.Show()
-		s.mu.Unlock()
-		return
+	defer s.mu.Unlock()
+	if s.running {
+		return s.ctx
+	}
+	s.ctx, s.cancel = context.WithCancelCause(ctx)
+	s.running = true
+	s.started = time.Now()
+	s.stopChan = make(chan struct{})
+	s.stopped, s.listener = nil, nil
+	select {
+	case <-s.done:
+		s.done = make(chan struct{})
+	default:
 	}
-	s.mu.Unlock()
 
-	go keyboard.Listen(func(key keys.Key) (stop bool, err error) {
-		for _, c := range s.CancelKeys {
-			if key.Code == c {
-				s.stopChan <- struct{}{}
-				return true, nil // Stop listener by returning true on Ctrl+C
-			}
+	s.animated = s.animate()
+	if s.animated {
+		fmt.Fprint(s.Writer, "\033[?25l")
+		s.stopped = make(chan struct{})
+		go s.spin(s.stopChan, s.stopped)
+		if len(s.CancelKeys) > 0 && term.IsTerminal(int(os.Stdin.Fd())) {
+			s.listener = s.listen(s.cancel)
+		}
+	} else {
+		s.startCI()
+	}
+
+	ctx = s.ctx
+	context.AfterFunc(ctx, func() {
+		if !s.halt(ctx, nil) || !errors.Is(context.Cause(ctx), ErrCanceled) {
+			return
+		}
+		s.mu.RLock()
+		onCancel := s.OnCancel
+		s.mu.RUnlock()
+		if onCancel != nil {
+			onCancel()
 		}
-		return false, nil
 	})
+	return ctx
+}
 
-	go func() {
-		for {
-			for c := range s.variant.All() {
-				select {
-				case <-s.stopChan:
-					s.mu.Lock()
-					s.running = false
-					s.mu.Unlock()
-					fmt.Fprint(s.Writer, "\033[?25h")
-					os.Exit(0)
-				default:
-					s.mu.RLock()
-					out := fmt.Sprintf("\r%s%s%s", s.Prefix, s.Color.Sprintf("%s", c), s.Suffix)
-					fmt.Fprint(s.Writer, out)
-					delay := s.variant.Interval
-					s.mu.RUnlock()
-					time.Sleep(time.Duration(delay) * time.Millisecond)
-				}
+// Done returns a channel that is closed when the spinner stops, after its
+// final line is printed. It is replaced when the spinner is started again.
+func (s *Spinner) Done() <-chan struct{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.done
+}
+
+// spin draws the frames of the spinner until stop is closed, and closes stopped when it returns.
+func (s *Spinner) spin(stop, stopped chan struct{}) {
+	defer close(stopped)
+	for {
+		for c, delay := range s.variant.Frames() {
+			s.mu.Lock()
+			s.lastOut = s.Prefix + s.frame(c) + s.Suffix
+			fmt.Fprint(s.Writer, "\r"+s.lastOut)
+			s.mu.Unlock()
+
+			select {
+			case <-stop:
+				return
+			case <-time.After(delay):
 			}
 		}
+	}
+}
+
+// listener reads the cancel keys of a spinner from the keyboard.
+type listener struct {
+	mu       sync.Mutex
+	kp       keypress.Listener
+	stopping bool
+	done     chan struct{} // closed when the terminal is restored
+}
+
+// listen starts a listener that calls cancel with ErrCanceled when a cancel key is pressed.
+func (s *Spinner) listen(cancel context.CancelCauseFunc) *listener {
+	l := &listener{done: make(chan struct{})}
+	cancelKeys := slices.Clone(s.CancelKeys)
+	go func() {
+		defer close(l.done)
+		_ = l.kp.Listen(func(key keys.Key) (stop bool, err error) {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			if l.stopping {
+				return true, nil
+			}
+			if slices.Contains(cancelKeys, key.Code) {
+				l.stopping = true
+				cancel(ErrCanceled)
+				return true, nil
+			}
+			return false, nil
+		})
 	}()
+	return l
 }
 
-// Stop stops the spinner, prints the final message if set, and signals the stop channel.
-func (s *Spinner) Stop() {
+// stop stops the listener and waits until it restored the terminal.
+func (l *listener) stop() {
+	l.mu.Lock()
+	wake := !l.stopping
+	l.stopping = true
+	l.mu.Unlock()
+	if wake {
+		// a null key press lets the listener see that it is stopping
+		l.kp.Interrupt()
+	}
+	<-l.done
+}
+
+// halt stops the render loop and the keyboard listener, erases the spinner
+// and calls final, if not nil, to print its final line. If ctx is not nil,
+// only the run started with ctx is stopped. halt reports whether it stopped
+// the spinner, so only the first of concurrent calls gets true.
+func (s *Spinner) halt(ctx context.Context, final func()) bool {
+	s.mu.Lock()
+	if !s.running || (ctx != nil && ctx != s.ctx) {
+		s.mu.Unlock()
+		return false
+	}
 	s.running = false
+	stop, stopped, l, cancel, done := s.stopChan, s.stopped, s.listener, s.cancel, s.done
+	s.mu.Unlock()
+
+	close(stop)
+	if l != nil {
+		l.stop()
+	}
+	if stopped != nil {
+		<-stopped
+	}
+
 	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.erase()
-	fmt.Fprint(s.Writer, "\033[?25h")
-	s.stopChan <- struct{}{}
-	if s.FinalMsg != "" {
+	if s.animated {
 		s.erase()
-		fmt.Fprintln(s.Writer, s.FinalMsg)
+		fmt.Fprint(s.Writer, "\033[?25h")
+		_ = s.lines.end()
+	} else {
+		_ = s.lines.end()
+		s.endCI()
+	}
+	if final != nil {
+		final()
 	}
+	s.mu.Unlock()
+	cancel(nil)
+	close(done)
+	return true
+}
+
+// Stop stops the spinner and prints the final message if set. In CI mode a
+// final line with the elapsed time is printed if there is no final message.
+// It is safe to call from any goroutine and does nothing if the spinner is not running.
+func (s *Spinner) Stop() {
+	s.halt(nil, func() {
+		switch {
+		case s.FinalMsg != "":
+			fmt.Fprintln(s.Writer, s.FinalMsg)
+		case !s.animated:
+			fmt.Fprintf(s.Writer, "%s done (%s)\n", s.label(), formatElapsed(time.Since(s.started)))
+		}
+	})
 }
 
 // Restart stops the spinner and starts it again.
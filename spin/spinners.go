@@ -1,19 +1,35 @@
 package spin
 
-import "iter"
+import (
+	"iter"
+	"time"
+)
 
 // CharSet is a type alias for a slice of strings.
 type CharSet = []string
 
 // SpinnerVariant represents a variant of a spinner with a specific character set and interval.
+// Frames may be several characters wide and may carry their own colors, in
+// which case the color of the spinner is not applied to them.
 type SpinnerVariant struct {
-	chars    CharSet
-	Interval int
+	chars     CharSet
+	durations []time.Duration // per frame, zero uses Interval
+	Interval  int
+	// PingPong plays the frames forward and then backward instead of starting over.
+	PingPong bool
+}
+
+// Frame is a frame of a spinner variant.
+type Frame struct {
+	Text string
+	// Duration is how long the frame is shown. Zero uses the interval of the variant.
+	Duration time.Duration
 }
 
+// All returns the frames of a cycle of the variant in the order they are played.
 func (v *SpinnerVariant) All() iter.Seq[string] {
 	return func(yield func(string) bool) {
-		for _, c := range v.chars {
+		for c := range v.Frames() {
 			if !yield(c) {
 				return
 			}
@@ -21,11 +37,95 @@ func (v *SpinnerVariant) All() iter.Seq[string] {
 	}
 }
 
+// Frames returns the frames of a cycle of the variant in the order they are
+// played, together with how long each of them is shown.
+func (v *SpinnerVariant) Frames() iter.Seq2[string, time.Duration] {
+	return func(yield func(string, time.Duration) bool) {
+		for _, i := range v.order() {
+			if !yield(v.chars[i], v.duration(i)) {
+				return
+			}
+		}
+	}
+}
+
+// order returns the indexes of the frames of a cycle.
+func (v *SpinnerVariant) order() []int {
+	n := len(v.chars)
+	order := make([]int, 0, 2*n)
+	for i := range n {
+		order = append(order, i)
+	}
+	if v.PingPong {
+		for i := n - 2; i > 0; i-- {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// duration returns how long frame i is shown.
+func (v *SpinnerVariant) duration(i int) time.Duration {
+	if i < len(v.durations) && v.durations[i] > 0 {
+		return v.durations[i]
+	}
+	return time.Duration(max(v.Interval, 1)) * time.Millisecond
+}
+
+// Duration returns the length of a cycle of the variant.
+func (v *SpinnerVariant) Duration() time.Duration {
+	var total time.Duration
+	for _, d := range v.Frames() {
+		total += d
+	}
+	return total
+}
+
+// FrameAt returns the frame that is shown d after the variant started playing.
+func (v *SpinnerVariant) FrameAt(d time.Duration) string {
+	total := v.Duration()
+	if total <= 0 {
+		return ""
+	}
+	d %= total
+	for c, length := range v.Frames() {
+		if d < length {
+			return c
+		}
+		d -= length
+	}
+	return ""
+}
+
+// tick returns the duration of the shortest frame, which is how often a
+// render loop has to redraw the variant.
+func (v *SpinnerVariant) tick() time.Duration {
+	tick := v.duration(0)
+	for i := range v.chars {
+		tick = min(tick, v.duration(i))
+	}
+	return tick
+}
+
 // NewSpinnerVariant creates a new SpinnerVariant with the given character set and interval.
 func NewSpinnerVariant(charSet CharSet, interval int) SpinnerVariant {
 	return SpinnerVariant{chars: charSet, Interval: interval}
 }
 
+// NewSpinnerVariantFrames creates a new SpinnerVariant from frames with their
+// own durations. Frames without a duration are shown for interval milliseconds.
+func NewSpinnerVariantFrames(interval int, frames ...Frame) SpinnerVariant {
+	v := SpinnerVariant{
+		chars:     make(CharSet, len(frames)),
+		durations: make([]time.Duration, len(frames)),
+		Interval:  interval,
+	}
+	for i, f := range frames {
+		v.chars[i], v.durations[i] = f.Text, f.Duration
+	}
+	return v
+}
+
 var (
 	// GrowVertical is a spinner variant that grows the spinner vertically.
 	GrowVertical = NewSpinnerVariant(CharSets[0], 80)
@@ -55,7 +155,9 @@ var (
 	MovingDots = NewSpinnerVariant(CharSets[12], 80)
 )
 
-// CharSets contains the available character sets
+// CharSets contains the available character sets.
+//
+// Deprecated: use Lookup and Names to find variants by name.
 var CharSets = map[int][]string{
 	0: {"▁", "▃", "▄", "▅", "▆", "▇", "█", "▇", "▆", "▅", "▄", "▃", "▁"},
 	1: {"▖", "▘", "▝", "▗"},
@@ -0,0 +1,124 @@
+package spin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stelmanjones/termtools/theme"
+)
+
+// Glyphs are the symbols that replace a spinner when it ends with a status.
+type Glyphs struct {
+	Success string
+	Fail    string
+	Warn    string
+	Info    string
+}
+
+// DefaultGlyphs are the glyphs used by spinners and groups unless set otherwise.
+var DefaultGlyphs = Glyphs{
+	Success: "✓",
+	Fail:    "✗",
+	Warn:    "⚠",
+	Info:    "ℹ",
+}
+
+// formatElapsed rounds d to a precision that suits its length.
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return d.Round(time.Millisecond).String()
+	case d < time.Minute:
+		return d.Round(100 * time.Millisecond).String()
+	default:
+		return d.Round(time.Second).String()
+	}
+}
+
+// styles returns the active theme of the spinner.
+func (s *Spinner) styles() *theme.Theme {
+	if s.theme == nil {
+		return theme.Current()
+	}
+	return s.theme
+}
+
+// SetGlyphs sets the glyphs printed by Success, Fail, Warn and Info.
+func (s *Spinner) SetGlyphs(g Glyphs) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.glyphs = g
+}
+
+// SetElapsed sets whether the time the spinner ran for is added to its final line.
+func (s *Spinner) SetElapsed(show bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.elapsed = show
+}
+
+// status is the way a spinner ended.
+type status int
+
+const (
+	statusSuccess status = iota
+	statusFail
+	statusWarn
+	statusInfo
+)
+
+// render returns the styled glyph of st.
+func (st status) render(g Glyphs, t *theme.Theme) string {
+	switch st {
+	case statusFail:
+		return t.Error.Render(g.Fail)
+	case statusWarn:
+		return t.Warning.Render(g.Warn)
+	case statusInfo:
+		return t.Info.Render(g.Info)
+	}
+	return t.Success.Render(g.Success)
+}
+
+// Success stops the spinner and replaces it with the success glyph and msg.
+func (s *Spinner) Success(msg string) {
+	s.finish(statusSuccess, msg)
+}
+
+// Fail stops the spinner and replaces it with the fail glyph and err.
+func (s *Spinner) Fail(err error) {
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	s.finish(statusFail, msg)
+}
+
+// Warn stops the spinner and replaces it with the warning glyph and msg.
+func (s *Spinner) Warn(msg string) {
+	s.finish(statusWarn, msg)
+}
+
+// Info stops the spinner and replaces it with the info glyph and msg.
+func (s *Spinner) Info(msg string) {
+	s.finish(statusInfo, msg)
+}
+
+// finish stops the spinner and prints its final line. Only the first call
+// after Start prints anything, so it is safe to call from several goroutines.
+// An empty msg falls back to the suffix of the spinner. In CI mode the
+// elapsed time is always added.
+func (s *Spinner) finish(st status, msg string) {
+	s.halt(nil, func() {
+		if msg == "" {
+			msg = strings.TrimSpace(s.Suffix)
+		}
+		t := s.styles()
+		line := st.render(s.glyphs, t) + " " + msg
+		if s.elapsed || !s.animated {
+			line += t.Muted.Render(fmt.Sprintf(" (%s)", formatElapsed(time.Since(s.started))))
+		}
+		fmt.Fprintln(s.Writer, line)
+	})
+}
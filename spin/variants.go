@@ -0,0 +1,137 @@
+package spin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// registry holds the variants that can be looked up by name.
+var registry = struct {
+	sync.RWMutex
+	variants map[string]SpinnerVariant
+}{variants: map[string]SpinnerVariant{
+	"arc":             Arc,
+	"bounce":          Bounce,
+	"bouncing-bar":    BouncingBar,
+	"bouncing-simple": BouncingSimple,
+	"dots1":           Dots1,
+	"dots2":           Dots2,
+	"dots3":           Dots3,
+	"grow-horizontal": GrowHorizontal,
+	"grow-hv":         GrowHV,
+	"grow-vertical":   GrowVertical,
+	"letters":         Letters,
+	"moving-dots":     MovingDots,
+	"simple":          Simple,
+}}
+
+// Register adds a variant under name, replacing a variant of the same name.
+func Register(name string, v SpinnerVariant) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.variants[name] = v
+}
+
+// Lookup returns the variant registered under name.
+func Lookup(name string) (SpinnerVariant, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	v, ok := registry.variants[name]
+	return v, ok
+}
+
+// Names returns the names of the registered variants in alphabetical order.
+func Names() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.variants))
+	for name := range registry.variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// variantFile is a variant in the format of cli-spinners, extended with
+// optional per-frame durations in milliseconds and ping-pong playback.
+type variantFile struct {
+	Interval  int      `json:"interval"`
+	Frames    []string `json:"frames"`
+	Durations []int    `json:"durations,omitempty"`
+	PingPong  bool     `json:"pingPong,omitempty"`
+}
+
+// variant checks f and turns it into a SpinnerVariant.
+func (f variantFile) variant(name string) (SpinnerVariant, error) {
+	if len(f.Frames) == 0 {
+		return SpinnerVariant{}, fmt.Errorf("%w: %s has no frames", ErrInvalidVariant, name)
+	}
+	if len(f.Durations) > len(f.Frames) {
+		return SpinnerVariant{}, fmt.Errorf("%w: %s has more durations than frames", ErrInvalidVariant, name)
+	}
+	if f.Interval <= 0 && len(f.Durations) < len(f.Frames) {
+		return SpinnerVariant{}, fmt.Errorf("%w: %s has no interval", ErrInvalidVariant, name)
+	}
+
+	frames := make([]Frame, len(f.Frames))
+	for i, text := range f.Frames {
+		frames[i].Text = text
+		if i < len(f.Durations) {
+			if f.Durations[i] <= 0 && f.Interval <= 0 {
+				return SpinnerVariant{}, fmt.Errorf("%w: frame %d of %s has no duration", ErrInvalidVariant, i, name)
+			}
+			frames[i].Duration = time.Duration(f.Durations[i]) * time.Millisecond
+		}
+	}
+	v := NewSpinnerVariantFrames(f.Interval, frames...)
+	v.PingPong = f.PingPong
+	return v, nil
+}
+
+// LoadVariants reads variants from a JSON file in the format of cli-spinners,
+// which maps names to variants. A file that holds a single variant is named
+// after the file. Besides "interval" and "frames", a variant may set
+// "durations" for its frames in milliseconds and "pingPong".
+//
+//	{
+//	  "dots": {"interval": 80, "frames": ["⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"]},
+//	  "pulse": {"interval": 100, "frames": ["·", "•", "●"], "durations": [100, 100, 400], "pingPong": true}
+//	}
+//
+// The variants are not registered; use Register to look them up by name.
+func LoadVariants(path string) (map[string]SpinnerVariant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var single variantFile
+	if err := json.Unmarshal(data, &single); err == nil && len(single.Frames) > 0 {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		v, err := single.variant(name)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]SpinnerVariant{name: v}, nil
+	}
+
+	var files map[string]variantFile
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, err
+	}
+	variants := make(map[string]SpinnerVariant, len(files))
+	for name, f := range files {
+		v, err := f.variant(name)
+		if err != nil {
+			return nil, err
+		}
+		variants[name] = v
+	}
+	return variants, nil
+}
@@ -0,0 +1,136 @@
+package theme
+
+import "sort"
+
+// Dark returns a theme for terminals with a dark background.
+func Dark() *Theme {
+	const (
+		red      = "#ff8272"
+		green    = "#b4fa72"
+		yellow   = "#fefdc2"
+		neonBlue = "#1ec9ff"
+		pink     = "#ff8ffd"
+		white    = "#f1f1f1"
+	)
+	return &Theme{
+		Name:          "dark",
+		Title:         Style{Foreground: white, Bold: true},
+		Text:          Style{Foreground: white},
+		Muted:         Style{Faint: true},
+		Accent:        Style{Foreground: pink},
+		Selected:      Style{Foreground: green, Bold: true},
+		Unselected:    Style{Faint: true},
+		Error:         Style{Foreground: red},
+		Warning:       Style{Foreground: yellow, Bold: true},
+		Success:       Style{Foreground: green, Bold: true},
+		Info:          Style{Foreground: neonBlue, Bold: true},
+		Spinner:       Style{Foreground: neonBlue},
+		Progress:      Style{Foreground: neonBlue},
+		Gradient:      []string{neonBlue, pink},
+		Selector:      "❯",
+		SelectorStyle: Style{Foreground: red},
+	}
+}
+
+// Light returns a theme for terminals with a light background.
+func Light() *Theme {
+	const (
+		red    = "#c0392b"
+		green  = "#2e7d32"
+		yellow = "#b36b00"
+		blue   = "#0063b1"
+		purple = "#8e24aa"
+		black  = "#1f1f1f"
+	)
+	return &Theme{
+		Name:          "light",
+		Title:         Style{Foreground: black, Bold: true},
+		Text:          Style{Foreground: black},
+		Muted:         Style{Faint: true},
+		Accent:        Style{Foreground: purple},
+		Selected:      Style{Foreground: green, Bold: true},
+		Unselected:    Style{Faint: true},
+		Error:         Style{Foreground: red},
+		Warning:       Style{Foreground: yellow, Bold: true},
+		Success:       Style{Foreground: green, Bold: true},
+		Info:          Style{Foreground: blue, Bold: true},
+		Spinner:       Style{Foreground: blue},
+		Progress:      Style{Foreground: blue},
+		Selector:      "❯",
+		SelectorStyle: Style{Foreground: red},
+	}
+}
+
+// HighContrast returns a theme using only the bright ANSI colors and no faint text.
+func HighContrast() *Theme {
+	const (
+		red     = "9"
+		green   = "10"
+		yellow  = "11"
+		cyan    = "14"
+		magenta = "13"
+		white   = "15"
+	)
+	return &Theme{
+		Name:          "high-contrast",
+		Title:         Style{Foreground: white, Bold: true, Underline: true},
+		Text:          Style{Foreground: white},
+		Muted:         Style{Foreground: white},
+		Accent:        Style{Foreground: magenta, Bold: true},
+		Selected:      Style{Foreground: "0", Background: yellow, Bold: true},
+		Unselected:    Style{Foreground: white},
+		Error:         Style{Foreground: red, Bold: true},
+		Warning:       Style{Foreground: yellow, Bold: true},
+		Success:       Style{Foreground: green, Bold: true},
+		Info:          Style{Foreground: cyan, Bold: true},
+		Spinner:       Style{Foreground: cyan, Bold: true},
+		Progress:      Style{Foreground: cyan},
+		Border:        Style{Foreground: white},
+		Selector:      "▶",
+		SelectorStyle: Style{Foreground: yellow, Bold: true},
+	}
+}
+
+// Monochrome returns a theme without colors, which uses text attributes only.
+func Monochrome() *Theme {
+	return &Theme{
+		Name:          "monochrome",
+		Title:         Style{Bold: true},
+		Muted:         Style{Faint: true},
+		Accent:        Style{Underline: true},
+		Selected:      Style{Bold: true},
+		Unselected:    Style{Faint: true},
+		Error:         Style{Bold: true},
+		Warning:       Style{Bold: true},
+		Success:       Style{Bold: true},
+		Info:          Style{Bold: true},
+		Selector:      ">",
+		SelectorStyle: Style{Bold: true},
+	}
+}
+
+var builtins = map[string]func() *Theme{
+	"dark":          Dark,
+	"light":         Light,
+	"high-contrast": HighContrast,
+	"monochrome":    Monochrome,
+}
+
+// Builtin returns a new copy of the built-in theme with the given name.
+func Builtin(name string) (*Theme, bool) {
+	f, ok := builtins[name]
+	if !ok {
+		return nil, false
+	}
+	return f(), true
+}
+
+// Names returns the names of the built-in themes in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,12 @@
+// Package theme provides the styles used by the prompts, spinners, boxes and
+// kv logs of termtools.
+//
+// A Theme maps named roles, such as Title, Muted or Error, to styles. The
+// current theme is used by every component that has no theme of its own:
+//
+//	theme.Set(theme.HighContrast())
+//
+// Themes can also be loaded from TOML or JSON files, see Load. Colors are
+// reduced to the color depth of the terminal, and the monochrome theme is
+// used by default if NO_COLOR is set.
+package theme
@@ -0,0 +1,19 @@
+module github.com/stelmanjones/termtools/theme
+
+go 1.22.1
+
+require (
+	github.com/BurntSushi/toml v1.4.0
+	github.com/charmbracelet/lipgloss v0.10.0
+	github.com/muesli/termenv v0.15.2
+)
+
+require (
+	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
+	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/muesli/reflow v0.3.0 // indirect
+	github.com/rivo/uniseg v0.4.7 // indirect
+	golang.org/x/sys v0.19.0 // indirect
+)
@@ -0,0 +1,79 @@
+package theme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+var (
+	// ErrUnknownFormat is returned when a theme file is neither TOML nor JSON.
+	ErrUnknownFormat = errors.New("unknown theme file format")
+	// ErrUnknownTheme is returned when a theme extends a theme that is not built in.
+	ErrUnknownTheme = errors.New("unknown theme")
+)
+
+// Load reads a theme from a TOML or JSON file. The format is chosen by the
+// extension of the file.
+//
+// A theme file only has to contain the roles it changes. The other roles are
+// taken from the built-in theme named by "extends", or from Dark. A file that
+// sets the foreground of progress but no gradient drops the gradient of the
+// theme it extends, so the foreground is used.
+//
+//	extends = "light"
+//
+//	[selected]
+//	foreground = "#005f87"
+//	bold = true
+func Load(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var unmarshal func([]byte, any) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		unmarshal = toml.Unmarshal
+	case ".json":
+		unmarshal = json.Unmarshal
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+	}
+	return parse(data, unmarshal)
+}
+
+// parse decodes a theme on top of the built-in theme it extends.
+func parse(data []byte, unmarshal func([]byte, any) error) (*Theme, error) {
+	var header struct {
+		Extends  string `json:"extends" toml:"extends"`
+		Progress struct {
+			Foreground *string `json:"foreground" toml:"foreground"`
+		} `json:"progress" toml:"progress"`
+		Gradient []string `json:"gradient" toml:"gradient"`
+	}
+	if err := unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	if header.Extends == "" {
+		header.Extends = "dark"
+	}
+
+	t, ok := Builtin(header.Extends)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTheme, header.Extends)
+	}
+	if header.Progress.Foreground != nil && header.Gradient == nil {
+		t.Gradient = nil
+	}
+	if err := unmarshal(data, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,47 @@
+package theme_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stelmanjones/termtools/theme"
+)
+
+func load(t *testing.T, name, data string) *theme.Theme {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	th, err := theme.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return th
+}
+
+func TestLoadProgressForeground(t *testing.T) {
+	for name, data := range map[string]string{
+		"theme.toml": "[progress]\nforeground = \"#ff0000\"\n",
+		"theme.json": `{"progress": {"foreground": "#ff0000"}}`,
+	} {
+		th := load(t, name, data)
+		if th.Progress.Foreground != "#ff0000" || th.Gradient != nil {
+			t.Errorf("%s: got progress %q and gradient %v, want only #ff0000", name, th.Progress.Foreground, th.Gradient)
+		}
+	}
+}
+
+func TestLoadKeepsGradient(t *testing.T) {
+	th := load(t, "theme.toml", "[selected]\nbold = false\n")
+	if !slices.Equal(th.Gradient, theme.Dark().Gradient) {
+		t.Errorf("got gradient %v, want that of dark", th.Gradient)
+	}
+
+	th = load(t, "theme.toml", "gradient = [\"#000000\", \"#ffffff\"]\n[progress]\nforeground = \"#ff0000\"\n")
+	if !slices.Equal(th.Gradient, []string{"#000000", "#ffffff"}) {
+		t.Errorf("got gradient %v, want the one of the file", th.Gradient)
+	}
+}
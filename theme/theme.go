@@ -0,0 +1,119 @@
+package theme
+
+import (
+	"os"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Style describes how the text of a role is rendered.
+// Colors are hex values such as "#ff8272" or ANSI color numbers such as "9".
+// An empty color leaves the color of the terminal unchanged.
+type Style struct {
+	Foreground string `json:"foreground,omitempty" toml:"foreground"`
+	Background string `json:"background,omitempty" toml:"background"`
+	Bold       bool   `json:"bold,omitempty" toml:"bold"`
+	Faint      bool   `json:"faint,omitempty" toml:"faint"`
+	Italic     bool   `json:"italic,omitempty" toml:"italic"`
+	Underline  bool   `json:"underline,omitempty" toml:"underline"`
+	Reverse    bool   `json:"reverse,omitempty" toml:"reverse"`
+}
+
+// Lipgloss returns the style as a lipgloss.Style.
+func (s Style) Lipgloss() lipgloss.Style {
+	style := lipgloss.NewStyle().
+		Bold(s.Bold).
+		Faint(s.Faint).
+		Italic(s.Italic).
+		Underline(s.Underline).
+		Reverse(s.Reverse)
+	if s.Foreground != "" {
+		style = style.Foreground(lipgloss.Color(s.Foreground))
+	}
+	if s.Background != "" {
+		style = style.Background(lipgloss.Color(s.Background))
+	}
+	return style
+}
+
+// Render renders strs joined by spaces in the style.
+func (s Style) Render(strs ...string) string {
+	return s.Lipgloss().Render(strs...)
+}
+
+// Theme holds the styles of all roles used by termtools.
+type Theme struct {
+	Name string `json:"name,omitempty" toml:"name"`
+
+	Title      Style `json:"title" toml:"title"`           // titles of prompts, forms and boxes
+	Text       Style `json:"text" toml:"text"`             // regular text
+	Muted      Style `json:"muted" toml:"muted"`           // hints, help footers and inactive content
+	Accent     Style `json:"accent" toml:"accent"`         // highlighted text such as filters and banners
+	Selected   Style `json:"selected" toml:"selected"`     // the highlighted choice
+	Unselected Style `json:"unselected" toml:"unselected"` // all other choices
+	Error      Style `json:"error" toml:"error"`
+	Warning    Style `json:"warning" toml:"warning"`
+	Success    Style `json:"success" toml:"success"`
+	Info       Style `json:"info" toml:"info"`
+	Spinner    Style `json:"spinner" toml:"spinner"`   // the frames of spinners
+	Border     Style `json:"border" toml:"border"`     // the borders of boxes
+	Progress   Style `json:"progress" toml:"progress"` // the filled part of progress bars
+
+	// Gradient lists the colors the filled part of progress bars blends
+	// through from start to end. It takes precedence over the foreground of Progress.
+	Gradient []string `json:"gradient,omitempty" toml:"gradient"`
+
+	// Selector is the marker in front of the highlighted choice.
+	Selector      string `json:"selector,omitempty" toml:"selector"`
+	SelectorStyle Style  `json:"selector_style" toml:"selector_style"`
+}
+
+// RenderSelector returns the styled selector.
+func (t *Theme) RenderSelector() string {
+	return t.SelectorStyle.Render(t.Selector)
+}
+
+var (
+	mu      sync.RWMutex
+	current *Theme
+)
+
+// Current returns the theme used by all components without a theme of their own.
+// Unless Set was called, it is the theme returned by Default.
+func Current() *Theme {
+	mu.RLock()
+	t := current
+	mu.RUnlock()
+	if t != nil {
+		return t
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if current == nil {
+		current = Default()
+	}
+	return current
+}
+
+// Set sets the current theme. A nil theme restores the default.
+func Set(t *Theme) {
+	mu.Lock()
+	current = t
+	mu.Unlock()
+}
+
+// Default returns the theme that suits the terminal. It is Monochrome if
+// NO_COLOR is set or the terminal has no colors, otherwise Dark or Light
+// depending on the background of the terminal.
+func Default() *Theme {
+	if os.Getenv("NO_COLOR") != "" || lipgloss.ColorProfile() == termenv.Ascii {
+		return Monochrome()
+	}
+	if !lipgloss.HasDarkBackground() {
+		return Light()
+	}
+	return Dark()
+}
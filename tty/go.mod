@@ -2,6 +2,12 @@ module github.com/stelmanjones/termtools/tty
 
 go 1.23.0
 
-require golang.org/x/term v0.25.0
+require (
+	atomicgo.dev/keyboard v0.2.9
+	golang.org/x/term v0.25.0
+)
 
-require golang.org/x/sys v0.26.0 // indirect
+require (
+	github.com/containerd/console v1.0.4 // indirect
+	golang.org/x/sys v0.26.0 // indirect
+)
@@ -0,0 +1,120 @@
+// Package keypress listens for key presses with atomicgo.dev/keyboard and lets
+// other goroutines wake a listener up, for example when a context is done.
+//
+// The keyboard package can only be woken up by simulating a key press, which
+// is sent on an unbuffered channel shared by the whole process. A Listener
+// only sends one while it listens, and before it returns it waits until the
+// key press was received. So the key press never reaches a later listener
+// and the goroutine sending it doesn't leak.
+package keypress
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"atomicgo.dev/keyboard"
+	"atomicgo.dev/keyboard/keys"
+)
+
+// ErrListened is returned when Listen is called a second time on a Listener.
+var ErrListened = errors.New("keypress: listener was used already")
+
+// handoff is how long a stopping Listener waits for its wake-up key press to
+// be received. It is only reached if key presses are simulated elsewhere.
+const handoff = 100 * time.Millisecond
+
+// listen and simulate are replaced in tests.
+var (
+	listen   = keyboard.Listen
+	simulate = keyboard.SimulateKeyPress
+)
+
+// Listener listens for key presses once. Its zero value is ready to use.
+type Listener struct {
+	mu        sync.Mutex
+	listening bool
+	closed    bool          // Listen is returning or returned
+	pending   bool          // Interrupt was called before Listen
+	sent      chan struct{} // closed once the last wake-up key press was received
+}
+
+// Listen calls onKey for every key press until onKey returns true or an
+// error. A Listener listens only once; later calls return ErrListened.
+func (l *Listener) Listen(onKey func(key keys.Key) (stop bool, err error)) error {
+	l.mu.Lock()
+	if l.listening || l.closed {
+		l.mu.Unlock()
+		return ErrListened
+	}
+	l.listening = true
+	if l.pending {
+		l.wake()
+	}
+	l.mu.Unlock()
+
+	defer l.close()
+	return listen(func(key keys.Key) (bool, error) {
+		l.mu.Lock()
+		closed := l.closed
+		l.mu.Unlock()
+		if closed {
+			// a wake-up key press that arrived while stopping
+			return false, nil
+		}
+		stop, err := onKey(key)
+		if stop || err != nil {
+			l.close()
+		}
+		return stop, err
+	})
+}
+
+// Interrupt wakes Listen up with a keys.Null key press, so onKey can see that
+// it should stop. If Listen was not called yet, the key press is sent once it
+// is. Interrupt does nothing once Listen is stopping.
+func (l *Listener) Interrupt() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch {
+	case l.closed:
+	case !l.listening:
+		l.pending = true
+	default:
+		l.wake()
+	}
+}
+
+// wake sends a null key press, unless one is on its way already. It is
+// called with mu held.
+func (l *Listener) wake() {
+	if l.sent != nil {
+		select {
+		case <-l.sent:
+		default:
+			return
+		}
+	}
+	sent := make(chan struct{})
+	l.sent = sent
+	go func() {
+		_ = simulate(keys.Key{Code: keys.Null})
+		close(sent)
+	}()
+}
+
+// close stops Interrupt from sending key presses and waits until a key
+// press on its way was received, while the keyboard still listens for it.
+func (l *Listener) close() {
+	l.mu.Lock()
+	l.closed = true
+	sent := l.sent
+	l.mu.Unlock()
+	if sent == nil {
+		return
+	}
+	select {
+	case <-sent:
+	case <-time.After(handoff):
+	}
+}
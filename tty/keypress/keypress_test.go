@@ -0,0 +1,149 @@
+package keypress
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"atomicgo.dev/keyboard/keys"
+)
+
+// fakeKeyboard works like atomicgo.dev/keyboard: simulated key presses are
+// sent on an unbuffered channel, received by a goroutine that runs next to
+// the one reading the input, and once Listen stops that goroutine picks
+// between its cancel channel and a pending key press at random.
+type fakeKeyboard struct {
+	mock     chan keys.Key
+	input    chan keys.Key
+	inflight atomic.Int32 // simulated key presses that were not received
+}
+
+func newFakeKeyboard(t *testing.T) *fakeKeyboard {
+	f := &fakeKeyboard{mock: make(chan keys.Key), input: make(chan keys.Key)}
+	oldListen, oldSimulate := listen, simulate
+	listen, simulate = f.listen, f.simulate
+	t.Cleanup(func() { listen, simulate = oldListen, oldSimulate })
+	return f
+}
+
+func (f *fakeKeyboard) simulate(k ...any) error {
+	f.inflight.Add(1)
+	defer f.inflight.Add(-1)
+	f.mock <- k[0].(keys.Key)
+	return nil
+}
+
+func (f *fakeKeyboard) listen(onKey func(keys.Key) (bool, error)) error {
+	var stopped atomic.Bool
+	closed := make(chan struct{})
+	var closeInput sync.Once
+	cancel := make(chan bool)
+	go func() {
+		for {
+			select {
+			case <-cancel:
+				return
+			case k := <-f.mock:
+				if stop, _ := onKey(k); stop {
+					stopped.Store(true)
+					closeInput.Do(func() { close(closed) })
+				}
+			}
+		}
+	}()
+
+	for !stopped.Load() {
+		var k keys.Key
+		select {
+		case k = <-f.input:
+		case <-closed:
+		}
+		stop, err := onKey(k)
+		if err != nil {
+			return err
+		}
+		if stop {
+			closeInput.Do(func() { close(closed) })
+			break
+		}
+	}
+	cancel <- true
+	return nil
+}
+
+// stranded reports whether a simulated key press is still waiting to be
+// received after a while.
+func (f *fakeKeyboard) stranded() bool {
+	deadline := time.Now().Add(time.Second)
+	for f.inflight.Load() > 0 {
+		if time.Now().After(deadline) {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return false
+}
+
+func stopOn(code keys.KeyCode) func(keys.Key) (bool, error) {
+	return func(k keys.Key) (bool, error) { return k.Code == code, nil }
+}
+
+func TestInterrupt(t *testing.T) {
+	newFakeKeyboard(t)
+
+	var l Listener
+	done := make(chan error)
+	go func() { done <- l.Listen(stopOn(keys.Null)) }()
+	for {
+		l.Interrupt()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatal(err)
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestInterruptBeforeListen(t *testing.T) {
+	newFakeKeyboard(t)
+
+	var l Listener
+	l.Interrupt()
+	if err := l.Listen(stopOn(keys.Null)); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Listen(stopOn(keys.Null)); err != ErrListened {
+		t.Fatalf("got %v, want %v", err, ErrListened)
+	}
+}
+
+// TestInterruptWhileStopping interrupts listeners while they stop because of
+// a key press, before or after Listen returned. A wake-up key press must
+// never outlive its listener, or it blocks its goroutine and is read by the
+// next listener.
+func TestInterruptWhileStopping(t *testing.T) {
+	f := newFakeKeyboard(t)
+
+	for i := 0; i < 500; i++ {
+		var l Listener
+		done := make(chan error)
+		go func() { done <- l.Listen(stopOn(keys.Enter)) }()
+		f.input <- keys.Key{Code: keys.Enter}
+		interrupted := make(chan struct{})
+		go func() {
+			l.Interrupt()
+			close(interrupted)
+		}()
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+		<-interrupted
+		if f.stranded() {
+			t.Fatalf("wake-up key press of listener %d was never received", i)
+		}
+	}
+}